@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"strings"
+
+	"github.com/nccurry/go-kong/kong"
+)
+
+// initialisms are the words kept upper case in Go names.
+var initialisms = map[string]bool{
+	"acl": true, "api": true, "cpu": true, "dns": true, "dn": true, "hmac": true,
+	"http": true, "https": true, "id": true, "ip": true, "json": true, "jwt": true,
+	"ldap": true, "rsa": true, "ssl": true, "tcp": true, "tls": true, "ttl": true,
+	"udp": true, "uri": true, "url": true, "uuid": true,
+}
+
+// goName converts a plugin or field name such as rate-limiting or
+// hide_credentials to an exported Go name.
+func goName(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.' || r == ' '
+	}) {
+		switch {
+		case word == "uris":
+			b.WriteString("URIs")
+		case initialisms[strings.ToLower(word)]:
+			b.WriteString(strings.ToUpper(word))
+		default:
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+
+	s := b.String()
+	if s == "" || s[0] >= '0' && s[0] <= '9' {
+		s = "X" + s
+	}
+	return s
+}
+
+// record is a struct left to generate.
+type record struct {
+	name   string
+	doc    string
+	schema *kong.Schema
+}
+
+// generate returns the formatted source of a file of package pkg holding
+// the config structs of schemas, keyed by plugin name.
+func generate(pkg string, schemas map[string]*kong.Schema) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by kong-plugin-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %v\n", pkg)
+
+	for _, plugin := range sortedKeys(schemas) {
+		name := goName(plugin) + "Config"
+		writeRecord(&b, record{
+			name:   name,
+			doc:    fmt.Sprintf("%v is the config of the %v plugin.", name, plugin),
+			schema: schemas[plugin],
+		})
+	}
+
+	return format.Source(b.Bytes())
+}
+
+// writeRecord writes the struct for r to b, followed by the ones for the
+// records nested in it.
+func writeRecord(b *bytes.Buffer, r record) {
+	var nested []record
+
+	fmt.Fprintf(b, "\n// %v\n", r.doc)
+	fmt.Fprintf(b, "type %v struct {\n", r.name)
+	for _, name := range sortedKeys(r.schema.Fields) {
+		field := r.schema.Fields[name]
+		fieldName := goName(name)
+
+		typ, scalar := scalarType(field)
+		switch field.Type {
+		case "array", "set":
+			typ = "[]string"
+			if field.Elements != nil {
+				typ = "[]" + valueType(field.Elements)
+			}
+		case "map":
+			typ = "map[string]" + valueType(field.Values)
+		case "table", "record":
+			if field.Schema == nil {
+				typ = "map[string]interface{}"
+				break
+			}
+
+			n := record{name: strings.TrimSuffix(r.name, "Config") + fieldName, schema: field.Schema}
+			if field.Schema.Flexible {
+				n.doc = fmt.Sprintf("%v is a value of %v.%v, keyed by name.", n.name, r.name, fieldName)
+				typ = "map[string]*" + n.name
+			} else {
+				n.doc = fmt.Sprintf("%v is the record held in %v.%v.", n.name, r.name, fieldName)
+				typ = "*" + n.name
+			}
+			nested = append(nested, n)
+		}
+
+		// Optional scalars are pointers, so their zero value can be sent.
+		if scalar && !field.Required {
+			typ = "*" + typ
+		}
+
+		if doc := fieldDoc(field); doc != "" {
+			fmt.Fprintf(b, "\t// %v\n", doc)
+		}
+		fmt.Fprintf(b, "\t%v %v `json:\"%v,omitempty\"`\n", fieldName, typ, name)
+	}
+	fmt.Fprintf(b, "}\n")
+
+	for _, n := range nested {
+		writeRecord(b, n)
+	}
+}
+
+// scalarType returns the Go type of a string, boolean or number field, and
+// whether field is one.
+func scalarType(field *kong.SchemaField) (string, bool) {
+	switch field.Type {
+	case "string", "url":
+		return "string", true
+	case "boolean":
+		return "bool", true
+	case "integer":
+		return "int", true
+	case "number", "timestamp":
+		return "float64", true
+	}
+	return "interface{}", false
+}
+
+// valueType returns the Go type of the elements of an array or set, or of
+// the values of a map, described by field.
+func valueType(field *kong.SchemaField) string {
+	if field == nil {
+		return "interface{}"
+	}
+
+	switch field.Type {
+	case "array", "set":
+		return "[]" + valueType(field.Elements)
+	case "map":
+		return "map[string]" + valueType(field.Values)
+	}
+	typ, _ := scalarType(field)
+	return typ
+}
+
+// fieldDoc returns the doc comment of field, listing what the schema says
+// about it beyond its type.
+func fieldDoc(field *kong.SchemaField) string {
+	var parts []string
+	if field.Required {
+		parts = append(parts, "Required.")
+	}
+
+	if def := field.Default; def != nil {
+		// Lua encodes empty arrays as empty tables
+		if m, ok := def.(map[string]interface{}); ok && len(m) == 0 && field.Type == "array" {
+			def = []interface{}{}
+		}
+		if d, err := json.Marshal(def); err == nil {
+			parts = append(parts, fmt.Sprintf("Defaults to %s.", d))
+		}
+	}
+
+	switch {
+	case field.Elements != nil && len(field.Elements.Enum) > 0:
+		parts = append(parts, fmt.Sprintf("Each one of %v.", enumValues(field.Elements)))
+	case field.Keys != nil && len(field.Keys.Enum) > 0:
+		parts = append(parts, fmt.Sprintf("Keyed by one of %v.", enumValues(field.Keys)))
+	case len(field.Enum) > 0 && field.Type == "array":
+		parts = append(parts, fmt.Sprintf("Each one of %v.", enumValues(field)))
+	case len(field.Enum) > 0:
+		parts = append(parts, fmt.Sprintf("One of %v.", enumValues(field)))
+	}
+
+	if field.Immutable {
+		parts = append(parts, "Can't be changed once set.")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// enumValues returns the Enum of field, quoted and separated by commas.
+func enumValues(field *kong.SchemaField) string {
+	values := make([]string, len(field.Enum))
+	for i, e := range field.Enum {
+		values[i] = fmt.Sprintf("%q", fmt.Sprint(e))
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/nccurry/go-kong/kong"
+)
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"rate-limiting":    "RateLimiting",
+		"hide_credentials": "HideCredentials",
+		"ip-restriction":   "IPRestriction",
+		"hmac-auth":        "HMACAuth",
+		"redirect_uris":    "RedirectURIs",
+		"start_tls":        "StartTLS",
+		"2fa":              "X2fa",
+	}
+
+	for name, want := range tests {
+		if got := goName(name); got != want {
+			t.Errorf("goName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+// TestGenerate compares the structs generated for testdata/schemas with
+// testdata/plugins.golden. After a deliberate change to the output,
+// regenerate it with
+//
+//	go run . -dir testdata/schemas -package plugins -o testdata/plugins.golden
+func TestGenerate(t *testing.T) {
+	schemas, err := readDir("testdata/schemas")
+	if err != nil {
+		t.Fatalf("readDir returned error: %v", err)
+	}
+
+	got, err := generate("plugins", schemas)
+	if err != nil {
+		t.Fatalf("generate returned error: %v", err)
+	}
+
+	want, err := ioutil.ReadFile("testdata/plugins.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("generate returned\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGenerate_optional(t *testing.T) {
+	schemas := map[string]*kong.Schema{
+		"custom": {Fields: map[string]*kong.SchemaField{
+			"name":    {Type: "string", Required: true},
+			"enabled": {Type: "boolean"},
+			"extra":   {Type: "table"},
+			"other":   {Type: "function"},
+		}},
+	}
+
+	got, err := generate("main", schemas)
+	if err != nil {
+		t.Fatalf("generate returned error: %v", err)
+	}
+
+	want := "// Code generated by kong-plugin-gen. DO NOT EDIT.\n\n" +
+		"package main\n\n" +
+		"// CustomConfig is the config of the custom plugin.\n" +
+		"type CustomConfig struct {\n" +
+		"\tEnabled *bool                  `json:\"enabled,omitempty\"`\n" +
+		"\tExtra   map[string]interface{} `json:\"extra,omitempty\"`\n" +
+		"\t// Required.\n" +
+		"\tName  string      `json:\"name,omitempty\"`\n" +
+		"\tOther interface{} `json:\"other,omitempty\"`\n" +
+		"}\n"
+	if string(got) != want {
+		t.Errorf("generate returned\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestReadDir_empty(t *testing.T) {
+	if _, err := readDir(t.TempDir()); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
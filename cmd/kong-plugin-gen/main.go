@@ -0,0 +1,143 @@
+// Command kong-plugin-gen generates Go config structs for Kong plugins from
+// their schemas, as returned by GET /plugins/schema/{name}.
+//
+// Schemas are read either from a directory holding one {plugin name}.json
+// file per plugin:
+//
+//	kong-plugin-gen -dir testdata/schemas -package plugins -o plugins_gen.go
+//
+// or straight from a running Kong node, for the plugins named:
+//
+//	kong-plugin-gen -url http://localhost:8001 -o plugins_gen.go rate-limiting my-plugin
+//
+// It is meant to be run through go generate, i.e.
+//
+//	//go:generate go run github.com/nccurry/go-kong/cmd/kong-plugin-gen -dir schemas -o plugins_gen.go
+//
+// Schemas may be in the layout of Kong 0.x or 1.x, including the schema of
+// a whole 1.x plugin entity, of which only the config is generated.
+//
+// Each plugin gets a struct named after it, i.e. RateLimitingConfig, with
+// the json tags Kong expects. Optional booleans, numbers and strings are
+// pointers, so values deliberately set to their zero value are still sent.
+// The structs work with kong.TypedPlugin and kong.ToMap/FromMap alike.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nccurry/go-kong/kong"
+)
+
+func main() {
+	var (
+		dir     = flag.String("dir", "", "directory of {plugin name}.json schema files")
+		baseURL = flag.String("url", "", "admin API of a Kong node to fetch the schemas of the plugins named as arguments from")
+		pkg     = flag.String("package", "", "package of the generated file (default $GOPACKAGE or main)")
+		out     = flag.String("o", "", "file to write to (default stdout)")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: kong-plugin-gen [flags] -dir dir | -url url plugin...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("kong-plugin-gen: ")
+
+	var schemas map[string]*kong.Schema
+	var err error
+	switch {
+	case *dir != "" && *baseURL == "" && flag.NArg() == 0:
+		schemas, err = readDir(*dir)
+	case *baseURL != "" && *dir == "" && flag.NArg() > 0:
+		schemas, err = fetch(*baseURL, flag.Args())
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *pkg == "" {
+		*pkg = os.Getenv("GOPACKAGE")
+	}
+	if *pkg == "" {
+		*pkg = "main"
+	}
+
+	src, err := generate(*pkg, schemas)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readDir reads the schemas held in dir, keyed by plugin name.
+func readDir(dir string) (map[string]*kong.Schema, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no schema files in %v", dir)
+	}
+
+	schemas := make(map[string]*kong.Schema)
+	for _, file := range files {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		schema := new(kong.Schema)
+		if err := json.Unmarshal(b, schema); err != nil {
+			return nil, fmt.Errorf("%v: %v", file, err)
+		}
+		schemas[strings.TrimSuffix(filepath.Base(file), ".json")] = schema
+	}
+	return schemas, nil
+}
+
+// fetch queries the Kong node at baseURL for the schemas of plugins.
+func fetch(baseURL string, plugins []string) (map[string]*kong.Schema, error) {
+	client, err := kong.NewClient(nil, baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := make(map[string]*kong.Schema)
+	for _, name := range plugins {
+		schema, _, err := client.Plugins.GetConfigSchema(name)
+		if err != nil {
+			return nil, err
+		}
+		schemas[name] = schema
+	}
+	return schemas, nil
+}
+
+// sortedKeys returns the keys of m in order, so the output is stable.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
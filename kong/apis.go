@@ -1,6 +1,7 @@
 package kong
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -42,6 +43,10 @@ type ApiRequest struct {
 	UpstreamReadTimeout    int      `json:"upstream_read_timeout"`
 	HttpsOnly              bool      `json:"https_only"`
 	HttpIfTerminated       bool      `json:"http_if_terminated"`
+
+	// ForceSendFields lists the JSON names of fields, such as "strip_uri",
+	// which Upsert compares and sends even when they hold their zero value.
+	ForceSendFields []string `json:"-"`
 }
 
 // Api represents an existing Kong api object
@@ -67,9 +72,14 @@ type Api struct {
 //
 // Equivalent to GET /apis/{name or id}
 func (s *ApisService) Get(api string) (*Api, *http.Response, error) {
+	return s.GetWithContext(context.Background(), api)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *ApisService) GetWithContext(ctx context.Context, api string) (*Api, *http.Response, error) {
 	u := fmt.Sprintf("apis/%v", api)
 
-	req, err := s.client.NewRequest("GET", u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -85,37 +95,63 @@ func (s *ApisService) Get(api string) (*Api, *http.Response, error) {
 
 // Patch updates an existing Kong api object.
 // At least one of api.Name or api.ID must be specified in
-// the passed *Api parameter.
+// the passed *Api parameter. Use Update to get the updated object back.
 //
 // Equivalent to PATCH /apis/{name or id}
 func (s *ApisService) Patch(api *ApiRequest) (*http.Response, error) {
+	return s.PatchWithContext(context.Background(), api)
+}
+
+// PatchWithContext is like Patch but uses ctx for the request.
+func (s *ApisService) PatchWithContext(ctx context.Context, api *ApiRequest) (*http.Response, error) {
+	_, resp, err := s.UpdateWithContext(ctx, api)
+	return resp, err
+}
+
+// Update is like Patch but returns the updated Kong api object.
+//
+// Equivalent to PATCH /apis/{name or id}
+func (s *ApisService) Update(api *ApiRequest) (*Api, *http.Response, error) {
+	return s.UpdateWithContext(context.Background(), api)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *ApisService) UpdateWithContext(ctx context.Context, api *ApiRequest) (*Api, *http.Response, error) {
 	var u string
 	if api.Name != "" {
 		u = fmt.Sprintf("apis/%v", api.Name)
 	} else if api.ID != "" {
 		u = fmt.Sprintf("apis/%v", api.ID)
 	} else {
-		return nil, errors.New("At least one of api.Name or api.ID must be specified")
+		return nil, nil, errors.New("At least one of api.Name or api.ID must be specified")
 	}
 
-	req, err := s.client.NewRequest("PATCH", u, api)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, u, api)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	resp, err := s.client.Do(req, nil)
-
-	return resp, err
+	uResp := new(Api)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
 
+	return uResp, resp, err
 }
 
 // Delete deletes a single Kong api object, by name or id.
 //
 // Equivalent to DELETE /apis/{name or id}
 func (s *ApisService) Delete(api string) (*http.Response, error) {
+	return s.DeleteWithContext(context.Background(), api)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *ApisService) DeleteWithContext(ctx context.Context, api string) (*http.Response, error) {
 	u := fmt.Sprintf("apis/%v", api)
 
-	req, err := s.client.NewRequest("DELETE", u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "DELETE", u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -128,18 +164,77 @@ func (s *ApisService) Delete(api string) (*http.Response, error) {
 	return resp, err
 }
 
-// Post creates a new Kong api object.
+// Post creates a new Kong api object. Use Create to get the created
+// object back.
 //
 // Equivalent to POST /apis
 func (s *ApisService) Post(api *ApiRequest) (*http.Response, error) {
-	req, err := s.client.NewRequest("POST", "apis", api)
+	return s.PostWithContext(context.Background(), api)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *ApisService) PostWithContext(ctx context.Context, api *ApiRequest) (*http.Response, error) {
+	_, resp, err := s.CreateWithContext(ctx, api)
+	return resp, err
+}
+
+// Create is like Post but returns the created Kong api object, including
+// its id and the defaults Kong filled in.
+//
+// Equivalent to POST /apis
+func (s *ApisService) Create(api *ApiRequest) (*Api, *http.Response, error) {
+	return s.CreateWithContext(context.Background(), api)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *ApisService) CreateWithContext(ctx context.Context, api *ApiRequest) (*Api, *http.Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, "apis", api)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	resp, err := s.client.Do(req, nil)
+	uResp := new(Api)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return resp, err
+	return uResp, resp, err
+}
+
+// Upsert creates the Kong api object named api.Name, or identified by
+// api.ID, or updates it to match api. It reports whether the api was
+// created, updated or already matched.
+//
+// Fields of api left at their zero value are neither compared nor sent,
+// so the api keeps its current values for them, or Kong's defaults when
+// it is created. List a field in api.ForceSendFields to set it to its
+// zero value, i.e. strip_uri to false.
+//
+// Equivalent to PUT /apis, or to POST /apis or PATCH /apis/{name or id}
+// when Kong does not accept PUT
+func (s *ApisService) Upsert(api *ApiRequest) (*Api, UpsertResult, *http.Response, error) {
+	return s.UpsertWithContext(context.Background(), api)
+}
+
+// UpsertWithContext is like Upsert but uses ctx for the requests.
+func (s *ApisService) UpsertWithContext(ctx context.Context, api *ApiRequest) (*Api, UpsertResult, *http.Response, error) {
+	key := api.Name
+	if key == "" {
+		key = api.ID
+	}
+	if key == "" {
+		return nil, Unchanged, nil, errors.New("At least one of api.Name or api.ID must be specified")
+	}
+
+	desired, err := setFields(api, api.ForceSendFields)
+	if err != nil {
+		return nil, Unchanged, nil, err
+	}
+
+	return upsert(ctx, s.service, "apis", desired, func(ctx context.Context) (*Api, *http.Response, error) {
+		return found(s.GetWithContext(ctx, key))
+	})
 }
 
 // ApisGetAllOptions specifies optional filter parameters to the
@@ -163,12 +258,17 @@ type ApisGetAllOptions struct {
 //
 // Equivalent to GET /apis?uri=params&from=opt
 func (s *ApisService) GetAll(opt *ApisGetAllOptions) (*Apis, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *ApisService) GetAllWithContext(ctx context.Context, opt *ApisGetAllOptions) (*Apis, *http.Response, error) {
 	u, err := addOptions("apis", opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest("GET", u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -182,6 +282,42 @@ func (s *ApisService) GetAll(opt *ApisGetAllOptions) (*Apis, *http.Response, err
 	return apis, resp, err
 }
 
+// Iterator returns an Iterator over all Kong api objects.
+// opt.Size sets the page size and opt.Offset the starting point,
+// the remaining fields filter the results as in GetAll.
+func (s *ApisService) Iterator(opt *ApisGetAllOptions) *Iterator[Api] {
+	return s.IteratorWithContext(context.Background(), opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *ApisService) IteratorWithContext(ctx context.Context, opt *ApisGetAllOptions) *Iterator[Api] {
+	o := new(ApisGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+
+	return newIterator(o.Offset, func(offset string) ([]*Api, string, error) {
+		o.Offset = offset
+		apis, _, err := s.GetAllWithContext(ctx, o)
+		if err != nil {
+			return nil, "", err
+		}
+		return apis.Data, nextOffset(apis.Next, apis.Offset), nil
+	})
+}
+
+// ListAll follows Kong's pagination cursor and returns every api object
+// matching opt. If max is greater than zero no more than max objects are
+// returned.
+func (s *ApisService) ListAll(opt *ApisGetAllOptions, max int) ([]*Api, error) {
+	return s.ListAllWithContext(context.Background(), opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *ApisService) ListAllWithContext(ctx context.Context, opt *ApisGetAllOptions, max int) ([]*Api, error) {
+	return s.IteratorWithContext(ctx, opt).all(max)
+}
+
 // ApisPluginsService handles communication with Kong's '/apis/{api id or name}/plugins' resource.
 type ApisPluginsService service
 
@@ -190,12 +326,17 @@ type ApisPluginsService service
 //
 // Equivalent to GET/apis/{api}/plugins?uri=params&from=opt
 func (s *ApisPluginsService) GetAll(api string, opt *PluginsGetAllOptions) (*Plugins, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), api, opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *ApisPluginsService) GetAllWithContext(ctx context.Context, api string, opt *PluginsGetAllOptions) (*Plugins, *http.Response, error) {
 	u, err := addOptions(fmt.Sprintf("apis/%v/plugins", api), opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest("GET", u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -210,35 +351,107 @@ func (s *ApisPluginsService) GetAll(api string, opt *PluginsGetAllOptions) (*Plu
 }
 
 // Post creates a new Kong plugin object attached to the
-// specified api.
+// specified api. Use Create to get the created object back.
 //
 // Equivalent to POST /apis/{apiName}/plugins
 func (s *ApisPluginsService) Post(api string, plugin *Plugin) (*http.Response, error) {
+	return s.PostWithContext(context.Background(), api, plugin)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *ApisPluginsService) PostWithContext(ctx context.Context, api string, plugin *Plugin) (*http.Response, error) {
+	_, resp, err := s.CreateWithContext(ctx, api, plugin)
+	return resp, err
+}
+
+// Create is like Post but returns the created Kong plugin object,
+// including its id and the config defaults Kong filled in.
+//
+// Equivalent to POST /apis/{apiName}/plugins
+func (s *ApisPluginsService) Create(api string, plugin *Plugin) (*Plugin, *http.Response, error) {
+	return s.CreateWithContext(context.Background(), api, plugin)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *ApisPluginsService) CreateWithContext(ctx context.Context, api string, plugin *Plugin) (*Plugin, *http.Response, error) {
 	u := fmt.Sprintf("apis/%v/plugins", api)
 
-	req, err := s.client.NewRequest("POST", u, plugin)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, u, plugin)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	resp, err := s.client.Do(req, nil)
+	uResp := new(Plugin)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return resp, err
+	return uResp, resp, err
 }
 
 // Patch modifies the configuration of the specified plugin object attached
-// to the specified api. plugin.ID must be provided.
+// to the specified api. plugin.ID must be provided. Use Update to get the
+// updated object back.
 //
 // Equivalent to PATCH /apis/{apiName}/plugins/{pluginID}
 func (s *ApisPluginsService) Patch(api string, plugin *Plugin) (*http.Response, error) {
+	return s.PatchWithContext(context.Background(), api, plugin)
+}
+
+// PatchWithContext is like Patch but uses ctx for the request.
+func (s *ApisPluginsService) PatchWithContext(ctx context.Context, api string, plugin *Plugin) (*http.Response, error) {
+	_, resp, err := s.UpdateWithContext(ctx, api, plugin)
+	return resp, err
+}
+
+// Update is like Patch but returns the updated Kong plugin object.
+//
+// Equivalent to PATCH /apis/{apiName}/plugins/{pluginID}
+func (s *ApisPluginsService) Update(api string, plugin *Plugin) (*Plugin, *http.Response, error) {
+	return s.UpdateWithContext(context.Background(), api, plugin)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *ApisPluginsService) UpdateWithContext(ctx context.Context, api string, plugin *Plugin) (*Plugin, *http.Response, error) {
 	u := fmt.Sprintf("apis/%v/plugins/%v", api, plugin.ID)
 
-	req, err := s.client.NewRequest("PATCH", u, plugin)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, u, plugin)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	resp, err := s.client.Do(req, nil)
+	uResp := new(Plugin)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return resp, err
+	return uResp, resp, err
+}
+
+// Iterator returns an Iterator over all plugins attached to the
+// specified api.
+func (s *ApisPluginsService) Iterator(api string, opt *PluginsGetAllOptions) *Iterator[Plugin] {
+	return s.IteratorWithContext(context.Background(), api, opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *ApisPluginsService) IteratorWithContext(ctx context.Context, api string, opt *PluginsGetAllOptions) *Iterator[Plugin] {
+	return newPluginsIterator(opt, func(o *PluginsGetAllOptions) (*Plugins, error) {
+		plugins, _, err := s.GetAllWithContext(ctx, api, o)
+		return plugins, err
+	})
+}
+
+// ListAll returns every plugin attached to the specified api, following
+// Kong's pagination cursor. If max is greater than zero no more than max
+// plugins are returned.
+func (s *ApisPluginsService) ListAll(api string, opt *PluginsGetAllOptions, max int) ([]*Plugin, error) {
+	return s.ListAllWithContext(context.Background(), api, opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *ApisPluginsService) ListAllWithContext(ctx context.Context, api string, opt *PluginsGetAllOptions, max int) ([]*Plugin, error) {
+	return s.IteratorWithContext(ctx, api, opt).all(max)
 }
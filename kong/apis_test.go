@@ -225,6 +225,93 @@ func TestApisService_Post_badStatusCode(t *testing.T) {
 	}
 }
 
+func TestApisService_Create(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/apis", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"i","name":"n","uris":["/u"],"strip_uri":true,"retries":5}`)
+	})
+
+	api, _, err := client.Apis.Create(&ApiRequest{Name: "n", Uris: []string{"/u"}})
+	if err != nil {
+		t.Errorf("Apis.Create returned error: %v", err)
+	}
+
+	want := &Api{ID: "i", Name: "n", Uris: []string{"/u"}, StripUri: true, Retries: 5}
+	if !reflect.DeepEqual(api, want) {
+		t.Errorf("Apis.Create returned %+v, want %+v", api, want)
+	}
+}
+
+func TestApisService_Update(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/apis/n", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		fmt.Fprint(w, `{"id":"i","name":"n","retries":3}`)
+	})
+
+	api, _, err := client.Apis.Update(&ApiRequest{Name: "n", Retries: 3})
+	if err != nil {
+		t.Errorf("Apis.Update returned error: %v", err)
+	}
+
+	want := &Api{ID: "i", Name: "n", Retries: 3}
+	if !reflect.DeepEqual(api, want) {
+		t.Errorf("Apis.Update returned %+v, want %+v", api, want)
+	}
+
+	if _, _, err := client.Apis.Update(&ApiRequest{}); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestApisPluginsService_Create(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/apis/a/plugins", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"name":"cors"}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"p","name":"cors","api_id":"a","config":{"max_age":0}}`)
+	})
+
+	plugin, _, err := client.Apis.Plugins.Create("a", &Plugin{Name: "cors"})
+	if err != nil {
+		t.Errorf("Apis.Plugins.Create returned error: %v", err)
+	}
+
+	want := &Plugin{ID: "p", Name: "cors", ApiID: "a", Config: map[string]interface{}{"max_age": float64(0)}}
+	if !reflect.DeepEqual(plugin, want) {
+		t.Errorf("Apis.Plugins.Create returned %+v, want %+v", plugin, want)
+	}
+}
+
+func TestApisPluginsService_Update(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/apis/a/plugins/p", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		fmt.Fprint(w, `{"id":"p","name":"cors","enabled":false}`)
+	})
+
+	plugin, _, err := client.Apis.Plugins.Update("a", &Plugin{ID: "p", Enabled: Bool(false)})
+	if err != nil {
+		t.Errorf("Apis.Plugins.Update returned error: %v", err)
+	}
+
+	want := &Plugin{ID: "p", Name: "cors", Enabled: Bool(false)}
+	if !reflect.DeepEqual(plugin, want) {
+		t.Errorf("Apis.Plugins.Update returned %+v, want %+v", plugin, want)
+	}
+}
+
 func TestApisService_GetAll(t *testing.T) {
 	stubSetup()
 	defer stubTeardown()
@@ -295,3 +382,70 @@ func TestPost(t *testing.T) {
 	_, err = c.Apis.Post(apiRequest)
 	fmt.Errorf("api : %v", err)
 }
+
+func TestApisService_ListAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/apis", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("offset") {
+		case "":
+			testFormValues(t, r, values{"size": "2", "name": "n"})
+			fmt.Fprint(w, `{"data":[{"id":"1"},{"id":"2"}],"total":3,"offset":"o","next":"http://kong/apis?offset=o"}`)
+		case "o":
+			testFormValues(t, r, values{"size": "2", "name": "n", "offset": "o"})
+			fmt.Fprint(w, `{"data":[{"id":"3"}],"total":3}`)
+		}
+	})
+
+	apis, err := client.Apis.ListAll(&ApisGetAllOptions{Size: 2, Name: "n"}, 0)
+	if err != nil {
+		t.Errorf("Apis.ListAll returned error: %v", err)
+	}
+
+	want := []*Api{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	if !reflect.DeepEqual(apis, want) {
+		t.Errorf("Apis.ListAll returned %+v, want %+v", apis, want)
+	}
+}
+
+func TestApisService_ListAll_max(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/apis", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("offset") != "" {
+			t.Error("ListAll fetched a page beyond max")
+		}
+		fmt.Fprint(w, `{"data":[{"id":"1"},{"id":"2"}],"total":3,"offset":"o"}`)
+	})
+
+	apis, err := client.Apis.ListAll(nil, 1)
+	if err != nil {
+		t.Errorf("Apis.ListAll returned error: %v", err)
+	}
+
+	want := []*Api{{ID: "1"}}
+	if !reflect.DeepEqual(apis, want) {
+		t.Errorf("Apis.ListAll returned %+v, want %+v", apis, want)
+	}
+}
+
+func TestApisService_Iterator_badStatusCode(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/apis", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+		fmt.Fprint(w, `{"error":"e"}`)
+	})
+
+	it := client.Apis.Iterator(nil)
+	if it.Next() {
+		t.Error("Apis.Iterator Next returned true on error")
+	}
+	if it.Err() == nil {
+		t.Error("Expected error to be returned")
+	}
+}
@@ -0,0 +1,66 @@
+package kong
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// RequestSigner is called on every request built by NewRequest, once all
+// of its headers are set, so it can authenticate the request, i.e. by
+// adding a signature of it. An error returned by it is returned by
+// NewRequest.
+type RequestSigner func(req *http.Request) error
+
+// authenticate adds the credentials of c to req, then signs it.
+func (c *Client) authenticate(req *http.Request) error {
+	for k, v := range c.Header {
+		req.Header[k] = append([]string(nil), v...)
+	}
+
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	if c.Signer != nil {
+		return c.Signer(req)
+	}
+	return nil
+}
+
+// LoadTLSConfig returns a *tls.Config for an Admin API served over mutual
+// TLS. It presents the client certificate in the PEM encoded certFile and
+// keyFile, and trusts the PEM encoded CA bundle caFile instead of the
+// system roots. Either the certificate pair or caFile may be left empty.
+//
+// The config is meant to be passed to WithTLSConfig:
+//
+//	client, err := NewClientWithOptions("https://kong:8444/", WithTLSConfig(tlsConfig))
+func LoadTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	config := &tls.Config{}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if caFile != "" {
+		pem, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %v", caFile)
+		}
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
@@ -0,0 +1,157 @@
+package kong
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewRequest_header(t *testing.T) {
+	c, _ := NewClient(nil, "http://kong:8001/")
+	c.Header = http.Header{"Kong-Admin-Token": {"t"}, "Apikey": {"k"}}
+
+	req, _ := c.NewRequest("GET", "apis", nil)
+	if got := req.Header.Get("Kong-Admin-Token"); got != "t" {
+		t.Errorf("Kong-Admin-Token header = %q, want %q", got, "t")
+	}
+	if got := req.Header.Get("apikey"); got != "k" {
+		t.Errorf("apikey header = %q, want %q", got, "k")
+	}
+
+	// The client's headers must not be shared with the request
+	req.Header.Add("Apikey", "other")
+	if got := c.Header["Apikey"]; len(got) != 1 {
+		t.Errorf("Client.Header was modified through the request: %v", got)
+	}
+}
+
+func TestNewRequest_basicAuth(t *testing.T) {
+	c, _ := NewClient(nil, "http://kong:8001/")
+
+	req, _ := c.NewRequest("GET", "apis", nil)
+	if _, _, ok := req.BasicAuth(); ok {
+		t.Error("NewRequest set basic auth credentials without a Username")
+	}
+
+	c.Username, c.Password = "admin", "secret"
+	req, _ = c.NewRequest("GET", "apis", nil)
+	if u, p, ok := req.BasicAuth(); !ok || u != "admin" || p != "secret" {
+		t.Errorf("NewRequest basic auth = %v, %v, want admin, secret", u, p)
+	}
+}
+
+func TestNewRequest_signer(t *testing.T) {
+	c, _ := NewClient(nil, "http://kong:8001/")
+	c.Header = http.Header{"Date": {"d"}}
+	c.Signer = func(req *http.Request) error {
+		body, _ := req.GetBody()
+		b, _ := ioutil.ReadAll(body)
+		req.Header.Set("Signature", fmt.Sprintf("%v %v %v %s", req.Method, req.URL.Path, req.Header.Get("Date"), b))
+		return nil
+	}
+
+	req, _ := c.NewRequest("POST", "consumers", &Consumer{Username: "a"})
+	want := `POST /consumers d {"username":"a"}` + "\n"
+	if got := req.Header.Get("Signature"); got != want {
+		t.Errorf("Signature header = %q, want %q", got, want)
+	}
+}
+
+func TestNewRequest_signerError(t *testing.T) {
+	c, _ := NewClient(nil, "http://kong:8001/")
+	c.Signer = func(req *http.Request) error {
+		return errors.New("no key")
+	}
+
+	if _, err := c.NewRequest("GET", "apis", nil); err == nil || err.Error() != "no key" {
+		t.Errorf("NewRequest returned %v, want the signer's error", err)
+	}
+}
+
+// writeClientCert writes a self-signed client certificate and its key to
+// dir, returning their paths.
+func writeClientCert(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	return certFile, keyFile
+}
+
+func TestLoadTLSConfig(t *testing.T) {
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"id":"i"}`))
+	}))
+	s.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	s.StartTLS()
+	defer s.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	ioutil.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw}), 0600)
+	certFile, keyFile := writeClientCert(t, dir)
+
+	config, err := LoadTLSConfig(certFile, keyFile, caFile)
+	if err != nil {
+		t.Fatalf("LoadTLSConfig returned error: %v", err)
+	}
+
+	c, _ := NewClient(&http.Client{Transport: &http.Transport{TLSClientConfig: config}}, s.URL+"/")
+	api, _, err := c.Apis.Get("i")
+	if err != nil {
+		t.Fatalf("Apis.Get returned error: %v", err)
+	}
+	if api.ID != "i" {
+		t.Errorf("Apis.Get returned %+v, want id i", api)
+	}
+}
+
+func TestLoadTLSConfig_errors(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeClientCert(t, dir)
+
+	if _, err := LoadTLSConfig(certFile, "", ""); err == nil {
+		t.Error("LoadTLSConfig without a key returned no error")
+	}
+	if _, err := LoadTLSConfig("", "", filepath.Join(dir, "missing.crt")); err == nil {
+		t.Error("LoadTLSConfig of a missing CA bundle returned no error")
+	}
+	if _, err := LoadTLSConfig("", "", keyFile); err == nil {
+		t.Error("LoadTLSConfig of a CA bundle without certificates returned no error")
+	}
+}
@@ -0,0 +1,305 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// CertificatesService handles communication with Kong's '/certificates' resource.
+type CertificatesService struct {
+	*service
+	SNIs *CertificatesSNIsService
+}
+
+// Certificates represents the object returned from Kong when querying for
+// multiple certificate objects.
+//
+// In cases where the number of objects returned exceeds the maximum,
+// Next holds the URI for the next set of results.
+// i.e. "http://localhost:8001/certificates?offset=WyJmYjU3MjA1Ni1mODY1LTQ3"
+type Certificates struct {
+	Data   []*Certificate `json:"data,omitempty"`
+	Total  int            `json:"total,omitempty"`
+	Next   string         `json:"next,omitempty"`
+	Offset string         `json:"offset,omitempty"`
+}
+
+// Certificate represents a single Kong certificate object, a PEM encoded
+// public certificate and private key pair.
+//
+// SNIs lists the server names associated with the certificate. It may be
+// set when creating a certificate as a shorthand for creating the SNI
+// objects separately.
+type Certificate struct {
+	ID        string   `json:"id,omitempty"`
+	CreatedAt int64    `json:"created_at,omitempty"`
+	Cert      string   `json:"cert,omitempty"`
+	Key       string   `json:"key,omitempty"`
+	SNIs      []string `json:"snis,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+}
+
+// Get queries for a single Kong certificate object, by id or by one of
+// its SNI names.
+//
+// Equivalent to GET /certificates/{id or sni}
+func (s *CertificatesService) Get(certificate string) (*Certificate, *http.Response, error) {
+	return s.GetWithContext(context.Background(), certificate)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *CertificatesService) GetWithContext(ctx context.Context, certificate string) (*Certificate, *http.Response, error) {
+	u := fmt.Sprintf("certificates/%v", certificate)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Certificate)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Post creates a new Kong certificate object and returns it as stored
+// by Kong.
+//
+// Equivalent to POST /certificates
+func (s *CertificatesService) Post(certificate *Certificate) (*Certificate, *http.Response, error) {
+	return s.PostWithContext(context.Background(), certificate)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *CertificatesService) PostWithContext(ctx context.Context, certificate *Certificate) (*Certificate, *http.Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, "certificates", certificate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Certificate)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Patch updates an existing Kong certificate object, i.e. to rotate its
+// cert and key, and returns it as stored by Kong. certificate.ID must be
+// specified.
+//
+// Equivalent to PATCH /certificates/{id}
+func (s *CertificatesService) Patch(certificate *Certificate) (*Certificate, *http.Response, error) {
+	return s.PatchWithContext(context.Background(), certificate)
+}
+
+// PatchWithContext is like Patch but uses ctx for the request.
+func (s *CertificatesService) PatchWithContext(ctx context.Context, certificate *Certificate) (*Certificate, *http.Response, error) {
+	if certificate.ID == "" {
+		return nil, nil, errors.New("certificate.ID must be specified")
+	}
+
+	u := fmt.Sprintf("certificates/%v", certificate.ID)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, u, certificate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Certificate)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Delete deletes a single Kong certificate object, by id or by one of its
+// SNI names, together with its SNIs.
+//
+// Equivalent to DELETE /certificates/{id or sni}
+func (s *CertificatesService) Delete(certificate string) (*http.Response, error) {
+	return s.DeleteWithContext(context.Background(), certificate)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *CertificatesService) DeleteWithContext(ctx context.Context, certificate string) (*http.Response, error) {
+	u := fmt.Sprintf("certificates/%v", certificate)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+
+	return resp, err
+}
+
+// CertificatesGetAllOptions specifies optional filter parameters to the
+// CertificatesService.GetAll method.
+//
+// Additional information about filtering options can be found in
+// the Kong documentation at:
+// https://getkong.org/docs/0.13.x/admin-api/#list-certificates
+type CertificatesGetAllOptions struct {
+	Tags   string `url:"tags,omitempty"`   // A filter on the list based on tags, i.e. "a,b" or "a/b". Requires Kong 1.1 or later.
+	Size   int    `url:"size,omitempty"`   // A limit on the number of objects to be returned.
+	Offset string `url:"offset,omitempty"` // A cursor used for pagination. offset is an object identifier that defines a place in the list.
+}
+
+// GetAll queries for all Kong certificate objects.
+// This query can be filtered by supplying the CertificatesGetAllOptions struct.
+//
+// Equivalent to GET /certificates?uri=params&from=opt
+func (s *CertificatesService) GetAll(opt *CertificatesGetAllOptions) (*Certificates, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *CertificatesService) GetAllWithContext(ctx context.Context, opt *CertificatesGetAllOptions) (*Certificates, *http.Response, error) {
+	u, err := addOptions("certificates", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certificates := new(Certificates)
+	resp, err := s.client.Do(req, certificates)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return certificates, resp, err
+}
+
+// Iterator returns an Iterator over all Kong certificate
+// objects. opt.Size sets the page size and opt.Offset the starting point.
+func (s *CertificatesService) Iterator(opt *CertificatesGetAllOptions) *Iterator[Certificate] {
+	return s.IteratorWithContext(context.Background(), opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *CertificatesService) IteratorWithContext(ctx context.Context, opt *CertificatesGetAllOptions) *Iterator[Certificate] {
+	o := new(CertificatesGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+
+	return newIterator(o.Offset, func(offset string) ([]*Certificate, string, error) {
+		o.Offset = offset
+		certificates, _, err := s.GetAllWithContext(ctx, o)
+		if err != nil {
+			return nil, "", err
+		}
+		return certificates.Data, nextOffset(certificates.Next, certificates.Offset), nil
+	})
+}
+
+// ListAll follows Kong's pagination cursor and returns every certificate
+// object matching opt. If max is greater than zero no more than max
+// objects are returned.
+func (s *CertificatesService) ListAll(opt *CertificatesGetAllOptions, max int) ([]*Certificate, error) {
+	return s.ListAllWithContext(context.Background(), opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *CertificatesService) ListAllWithContext(ctx context.Context, opt *CertificatesGetAllOptions, max int) ([]*Certificate, error) {
+	return s.IteratorWithContext(ctx, opt).all(max)
+}
+
+// CertificatesSNIsService handles communication with Kong's
+// '/certificates/{id}/snis' resource.
+type CertificatesSNIsService service
+
+// GetAll lists all SNIs associated with the specified certificate.
+//
+// Equivalent to GET /certificates/{id}/snis?uri=params&from=opt
+func (s *CertificatesSNIsService) GetAll(certificate string, opt *SNIsGetAllOptions) (*SNIs, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), certificate, opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *CertificatesSNIsService) GetAllWithContext(ctx context.Context, certificate string, opt *SNIsGetAllOptions) (*SNIs, *http.Response, error) {
+	u, err := addOptions(fmt.Sprintf("certificates/%v/snis", certificate), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	snis := new(SNIs)
+	resp, err := s.client.Do(req, snis)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return snis, resp, err
+}
+
+// Post creates a new Kong SNI object associated with the specified
+// certificate and returns it as stored by Kong.
+//
+// Equivalent to POST /certificates/{id}/snis
+func (s *CertificatesSNIsService) Post(certificate string, sni *SNI) (*SNI, *http.Response, error) {
+	return s.PostWithContext(context.Background(), certificate, sni)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *CertificatesSNIsService) PostWithContext(ctx context.Context, certificate string, sni *SNI) (*SNI, *http.Response, error) {
+	u := fmt.Sprintf("certificates/%v/snis", certificate)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, u, sni)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(SNI)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Iterator returns an Iterator over all SNIs associated with the
+// specified certificate.
+func (s *CertificatesSNIsService) Iterator(certificate string, opt *SNIsGetAllOptions) *Iterator[SNI] {
+	return s.IteratorWithContext(context.Background(), certificate, opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *CertificatesSNIsService) IteratorWithContext(ctx context.Context, certificate string, opt *SNIsGetAllOptions) *Iterator[SNI] {
+	return newSNIsIterator(opt, func(o *SNIsGetAllOptions) (*SNIs, error) {
+		snis, _, err := s.GetAllWithContext(ctx, certificate, o)
+		return snis, err
+	})
+}
+
+// ListAll returns every SNI associated with the specified certificate,
+// following Kong's pagination cursor. If max is greater than zero no more
+// than max SNIs are returned.
+func (s *CertificatesSNIsService) ListAll(certificate string, opt *SNIsGetAllOptions, max int) ([]*SNI, error) {
+	return s.ListAllWithContext(context.Background(), certificate, opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *CertificatesSNIsService) ListAllWithContext(ctx context.Context, certificate string, opt *SNIsGetAllOptions, max int) ([]*SNI, error) {
+	return s.IteratorWithContext(ctx, certificate, opt).all(max)
+}
@@ -0,0 +1,187 @@
+package kong
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCertificatesService_Get(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/certificates/example.com", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"i","cert":"c","key":"k","snis":["example.com"]}`)
+	})
+
+	certificate, _, err := client.Certificates.Get("example.com")
+	if err != nil {
+		t.Errorf("Certificates.Get returned error: %v", err)
+	}
+
+	want := &Certificate{ID: "i", Cert: "c", Key: "k", SNIs: []string{"example.com"}}
+	if !reflect.DeepEqual(certificate, want) {
+		t.Errorf("Certificates.Get returned %+v, want %+v", certificate, want)
+	}
+}
+
+func TestCertificatesService_Get_badStatusCode(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/certificates/i", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+		fmt.Fprint(w, `{"error":"e"}`)
+	})
+
+	_, _, err := client.Certificates.Get("i")
+	if err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestCertificatesService_Post(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	input := &Certificate{Cert: "c", Key: "k", SNIs: []string{"example.com"}}
+
+	mux.HandleFunc("/certificates", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		v := new(Certificate)
+		json.NewDecoder(r.Body).Decode(v)
+		if !reflect.DeepEqual(v, input) {
+			t.Errorf("Request body = %+v, want %+v", v, input)
+		}
+		w.WriteHeader(201)
+		fmt.Fprint(w, `{"id":"i","cert":"c","key":"k","snis":["example.com"]}`)
+	})
+
+	certificate, _, err := client.Certificates.Post(input)
+	if err != nil {
+		t.Errorf("Certificates.Post returned error: %v", err)
+	}
+
+	want := &Certificate{ID: "i", Cert: "c", Key: "k", SNIs: []string{"example.com"}}
+	if !reflect.DeepEqual(certificate, want) {
+		t.Errorf("Certificates.Post returned %+v, want %+v", certificate, want)
+	}
+}
+
+func TestCertificatesService_Patch(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/certificates/i", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"id":"i","cert":"c2","key":"k2"}`+"\n")
+		fmt.Fprint(w, `{"id":"i","cert":"c2","key":"k2"}`)
+	})
+
+	certificate, _, err := client.Certificates.Patch(&Certificate{ID: "i", Cert: "c2", Key: "k2"})
+	if err != nil {
+		t.Errorf("Certificates.Patch returned error: %v", err)
+	}
+
+	want := &Certificate{ID: "i", Cert: "c2", Key: "k2"}
+	if !reflect.DeepEqual(certificate, want) {
+		t.Errorf("Certificates.Patch returned %+v, want %+v", certificate, want)
+	}
+}
+
+func TestCertificatesService_Patch_missingID(t *testing.T) {
+	_, _, err := client.Certificates.Patch(&Certificate{Cert: "c"})
+	if err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestCertificatesService_Delete(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/certificates/i", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(204)
+	})
+
+	_, err := client.Certificates.Delete("i")
+	if err != nil {
+		t.Errorf("Certificates.Delete returned error: %v", err)
+	}
+}
+
+func TestCertificatesService_Delete_invalidCertificate(t *testing.T) {
+	_, err := client.Certificates.Delete("%")
+	testURLParseError(t, err)
+}
+
+func TestCertificatesService_ListAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/certificates", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("offset") {
+		case "":
+			testFormValues(t, r, values{"size": "1"})
+			fmt.Fprint(w, `{"data":[{"id":"1"}],"offset":"o"}`)
+		case "o":
+			fmt.Fprint(w, `{"data":[{"id":"2"}]}`)
+		}
+	})
+
+	certificates, err := client.Certificates.ListAll(&CertificatesGetAllOptions{Size: 1}, 0)
+	if err != nil {
+		t.Errorf("Certificates.ListAll returned error: %v", err)
+	}
+
+	want := []*Certificate{{ID: "1"}, {ID: "2"}}
+	if !reflect.DeepEqual(certificates, want) {
+		t.Errorf("Certificates.ListAll returned %+v, want %+v", certificates, want)
+	}
+}
+
+func TestCertificatesSNIsService_GetAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/certificates/i/snis", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":[{"name":"example.com","certificate":{"id":"i"}}]}`)
+	})
+
+	snis, _, err := client.Certificates.SNIs.GetAll("i", nil)
+	if err != nil {
+		t.Errorf("Certificates.SNIs.GetAll returned error: %v", err)
+	}
+
+	want := &SNIs{Data: []*SNI{{Name: "example.com", Certificate: &Certificate{ID: "i"}}}}
+	if !reflect.DeepEqual(snis, want) {
+		t.Errorf("Certificates.SNIs.GetAll returned %+v, want %+v", snis, want)
+	}
+}
+
+func TestCertificatesSNIsService_Post(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/certificates/i/snis", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"name":"example.com"}`+"\n")
+		fmt.Fprint(w, `{"id":"s","name":"example.com","certificate":{"id":"i"}}`)
+	})
+
+	sni, _, err := client.Certificates.SNIs.Post("i", &SNI{Name: "example.com"})
+	if err != nil {
+		t.Errorf("Certificates.SNIs.Post returned error: %v", err)
+	}
+
+	want := &SNI{ID: "s", Name: "example.com", Certificate: &Certificate{ID: "i"}}
+	if !reflect.DeepEqual(sni, want) {
+		t.Errorf("Certificates.SNIs.Post returned %+v, want %+v", sni, want)
+	}
+}
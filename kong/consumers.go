@@ -1,6 +1,7 @@
 package kong
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -18,9 +19,10 @@ type ConsumersService struct {
 // URI for the next set of results.
 // i.e. "http://localhost:8001/consumers/?size=2&offset=4d924084-1adb-40a5-c042-63b19db421d1"
 type Consumers struct {
-	Data  []*Consumer `json:"data,omitempty"`
-	Total int         `json:"total,omitempty"`
-	Next  string      `json:"next,omitempty"`
+	Data   []*Consumer `json:"data,omitempty"`
+	Total  int         `json:"total,omitempty"`
+	Next   string      `json:"next,omitempty"`
+	Offset string      `json:"offset,omitempty"`
 }
 
 // Consumer represents a single Kong consumer object
@@ -35,9 +37,14 @@ type Consumer struct {
 //
 // Equivalent to GET /consumers/{name or id}
 func (s *ConsumersService) Get(consumer string) (*Consumer, *http.Response, error) {
+	return s.GetWithContext(context.Background(), consumer)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *ConsumersService) GetWithContext(ctx context.Context, consumer string) (*Consumer, *http.Response, error) {
 	u := fmt.Sprintf("consumers/%v", consumer)
 
-	req, err := s.client.NewRequest("GET", u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -53,36 +60,65 @@ func (s *ConsumersService) Get(consumer string) (*Consumer, *http.Response, erro
 
 // ConsumersService.Patch updates an existing Kong consumer object.
 // At least one of consumer.Username or consumer.ID must be specified
-// in the passed *Consumer parameter.
+// in the passed *Consumer parameter. Use Update to get the updated object
+// back.
 //
 // Equivalent to PATCH /consumers/{username or id}
 func (s *ConsumersService) Patch(consumer *Consumer) (*http.Response, error) {
+	return s.PatchWithContext(context.Background(), consumer)
+}
+
+// PatchWithContext is like Patch but uses ctx for the request.
+func (s *ConsumersService) PatchWithContext(ctx context.Context, consumer *Consumer) (*http.Response, error) {
+	_, resp, err := s.UpdateWithContext(ctx, consumer)
+	return resp, err
+}
+
+// ConsumersService.Update is like Patch but returns the updated Kong
+// consumer object.
+//
+// Equivalent to PATCH /consumers/{username or id}
+func (s *ConsumersService) Update(consumer *Consumer) (*Consumer, *http.Response, error) {
+	return s.UpdateWithContext(context.Background(), consumer)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *ConsumersService) UpdateWithContext(ctx context.Context, consumer *Consumer) (*Consumer, *http.Response, error) {
 	var u string
 	if consumer.ID != "" {
 		u = fmt.Sprintf("consumers/%v", consumer.ID)
 	} else if consumer.Username != "" {
 		u = fmt.Sprintf("consumers/%v", consumer.Username)
 	} else {
-		return nil, errors.New("At least one of consumer.Username or consumer.ID must be specified")
+		return nil, nil, errors.New("At least one of consumer.Username or consumer.ID must be specified")
 	}
 
-	req, err := s.client.NewRequest("PATCH", u, consumer)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, u, consumer)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	resp, err := s.client.Do(req, nil)
+	uResp := new(Consumer)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return resp, err
+	return uResp, resp, err
 }
 
 // ConsumersService.Delete deletes a single Kong consumer object, by name or id.
 //
 // Equivalent to DELETE /consumers/{username or id}
 func (s *ConsumersService) Delete(consumer string) (*http.Response, error) {
+	return s.DeleteWithContext(context.Background(), consumer)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *ConsumersService) DeleteWithContext(ctx context.Context, consumer string) (*http.Response, error) {
 	u := fmt.Sprintf("consumers/%v", consumer)
 
-	req, err := s.client.NewRequest("DELETE", u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "DELETE", u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -95,18 +131,77 @@ func (s *ConsumersService) Delete(consumer string) (*http.Response, error) {
 	return resp, err
 }
 
-// ConsumersService.Post creates a new Kong consumer object.
+// ConsumersService.Post creates a new Kong consumer object. Use Create to
+// get the created object back.
 //
 // Equivalent to POST /consumers
 func (s *ConsumersService) Post(consumer *Consumer) (*http.Response, error) {
-	req, err := s.client.NewRequest("POST", "consumers", consumer)
+	return s.PostWithContext(context.Background(), consumer)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *ConsumersService) PostWithContext(ctx context.Context, consumer *Consumer) (*http.Response, error) {
+	_, resp, err := s.CreateWithContext(ctx, consumer)
+	return resp, err
+}
+
+// ConsumersService.Create is like Post but returns the created Kong
+// consumer object, including its id.
+//
+// Equivalent to POST /consumers
+func (s *ConsumersService) Create(consumer *Consumer) (*Consumer, *http.Response, error) {
+	return s.CreateWithContext(context.Background(), consumer)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *ConsumersService) CreateWithContext(ctx context.Context, consumer *Consumer) (*Consumer, *http.Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, "consumers", consumer)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	resp, err := s.client.Do(req, nil)
+	uResp := new(Consumer)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return resp, err
+	return uResp, resp, err
+}
+
+// Upsert creates the Kong consumer object identified by
+// consumer.ID, consumer.Username or, failing those, consumer.CustomID, or
+// updates it to match consumer. It reports whether the consumer was
+// created, updated or already matched.
+//
+// Equivalent to PUT /consumers, or to POST /consumers or
+// PATCH /consumers/{id} when Kong does not accept PUT
+func (s *ConsumersService) Upsert(consumer *Consumer) (*Consumer, UpsertResult, *http.Response, error) {
+	return s.UpsertWithContext(context.Background(), consumer)
+}
+
+// UpsertWithContext is like Upsert but uses ctx for the requests.
+func (s *ConsumersService) UpsertWithContext(ctx context.Context, consumer *Consumer) (*Consumer, UpsertResult, *http.Response, error) {
+	find := func(ctx context.Context) (*Consumer, *http.Response, error) {
+		if consumer.ID != "" {
+			return found(s.GetWithContext(ctx, consumer.ID))
+		}
+		if consumer.Username != "" {
+			return found(s.GetWithContext(ctx, consumer.Username))
+		}
+
+		consumers, resp, err := s.GetAllWithContext(ctx, &ConsumersGetAllOptions{CustomID: consumer.CustomID})
+		if err != nil || len(consumers.Data) == 0 {
+			return nil, resp, err
+		}
+		return consumers.Data[0], resp, err
+	}
+
+	if consumer.ID == "" && consumer.Username == "" && consumer.CustomID == "" {
+		return nil, Unchanged, nil, errors.New("At least one of consumer.ID, consumer.Username or consumer.CustomID must be specified")
+	}
+
+	return upsert(ctx, s.service, "consumers", consumer, find)
 }
 
 // ConsumersGetAllOptions specifies optional filter parameters to the
@@ -128,12 +223,17 @@ type ConsumersGetAllOptions struct {
 //
 // Equivalent to GET /consumers?uri=params&from=opt
 func (s *ConsumersService) GetAll(opt *ConsumersGetAllOptions) (*Consumers, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *ConsumersService) GetAllWithContext(ctx context.Context, opt *ConsumersGetAllOptions) (*Consumers, *http.Response, error) {
 	u, err := addOptions("consumers", opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest("GET", u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -146,3 +246,39 @@ func (s *ConsumersService) GetAll(opt *ConsumersGetAllOptions) (*Consumers, *htt
 
 	return uResp, resp, err
 }
+
+// ConsumersService.Iterator returns an Iterator over all Kong
+// consumer objects. opt.Size sets the page size and opt.Offset the starting
+// point, the remaining fields filter the results as in GetAll.
+func (s *ConsumersService) Iterator(opt *ConsumersGetAllOptions) *Iterator[Consumer] {
+	return s.IteratorWithContext(context.Background(), opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *ConsumersService) IteratorWithContext(ctx context.Context, opt *ConsumersGetAllOptions) *Iterator[Consumer] {
+	o := new(ConsumersGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+
+	return newIterator(o.Offset, func(offset string) ([]*Consumer, string, error) {
+		o.Offset = offset
+		consumers, _, err := s.GetAllWithContext(ctx, o)
+		if err != nil {
+			return nil, "", err
+		}
+		return consumers.Data, nextOffset(consumers.Next, consumers.Offset), nil
+	})
+}
+
+// ConsumersService.ListAll follows Kong's pagination cursor and returns
+// every consumer object matching opt. If max is greater than zero no more
+// than max objects are returned.
+func (s *ConsumersService) ListAll(opt *ConsumersGetAllOptions, max int) ([]*Consumer, error) {
+	return s.ListAllWithContext(context.Background(), opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *ConsumersService) ListAllWithContext(ctx context.Context, opt *ConsumersGetAllOptions, max int) ([]*Consumer, error) {
+	return s.IteratorWithContext(ctx, opt).all(max)
+}
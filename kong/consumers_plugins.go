@@ -1,21 +1,35 @@
 package kong
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 )
 
 type ConsumersPlugins struct {
-	ACL     *ConsumersACLService
-	JWT     *ConsumersJWTService
-	KeyAuth *ConsumersKeyAuthService
+	ACL       *ConsumersACLService
+	JWT       *ConsumersJWTService
+	KeyAuth   *ConsumersKeyAuthService
+	BasicAuth *ConsumersBasicAuthService
+	HMACAuth  *ConsumersHMACAuthService
+	OAuth2    *ConsumersOAuth2Service
+}
+
+// ConsumerCredentialsGetAllOptions specifies the pagination parameters of
+// the methods listing the credentials of a single consumer.
+type ConsumerCredentialsGetAllOptions struct {
+	Size   int    `url:"size,omitempty"`   // A limit on the number of objects to be returned.
+	Offset string `url:"offset,omitempty"` // A cursor used for pagination. offset is an object identifier that defines a place in the list.
 }
 
 type ConsumersACLService service
 
 type ConsumerACLConfigs struct {
-	Data  []*ConsumerACLConfig `json:"data,omitempty"`
-	Total int                  `json:"total,omitempty"`
+	Data   []*ConsumerACLConfig `json:"data,omitempty"`
+	Total  int                  `json:"total,omitempty"`
+	Next   string               `json:"next,omitempty"`
+	Offset string               `json:"offset,omitempty"`
 }
 
 type ConsumerACLConfig struct {
@@ -26,22 +40,70 @@ type ConsumerACLConfig struct {
 }
 
 func (s *ConsumersACLService) Post(consumer string, config *ConsumerACLConfig) (*http.Response, error) {
+	return s.PostWithContext(context.Background(), consumer, config)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *ConsumersACLService) PostWithContext(ctx context.Context, consumer string, config *ConsumerACLConfig) (*http.Response, error) {
+	_, resp, err := s.CreateWithContext(ctx, consumer, config)
+	return resp, err
+}
+
+// ConsumersACLService.Create is like Post but returns the created acl,
+// including its id.
+//
+// Equivalent to POST /consumers/{username or id}/acls
+func (s *ConsumersACLService) Create(consumer string, config *ConsumerACLConfig) (*ConsumerACLConfig, *http.Response, error) {
+	return s.CreateWithContext(context.Background(), consumer, config)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *ConsumersACLService) CreateWithContext(ctx context.Context, consumer string, config *ConsumerACLConfig) (*ConsumerACLConfig, *http.Response, error) {
 	u := fmt.Sprintf("consumers/%v/acls", consumer)
 
-	req, err := s.client.NewRequest("POST", u, config)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, u, config)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	resp, err := s.client.Do(req, nil)
+	uResp := new(ConsumerACLConfig)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return resp, err
+	return uResp, resp, err
 }
 
+// GetAll queries for the acls of a consumer.
+//
+// Equivalent to GET /consumers/{username or id}/acls
 func (s *ConsumersACLService) GetAll(consumer string) (*ConsumerACLConfigs, *http.Response, error) {
-	u := fmt.Sprintf("consumers/%v/acls", consumer)
+	return s.GetAllWithContext(context.Background(), consumer)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *ConsumersACLService) GetAllWithContext(ctx context.Context, consumer string) (*ConsumerACLConfigs, *http.Response, error) {
+	return s.GetAllWithOptionsWithContext(ctx, consumer, nil)
+}
+
+// GetAllWithOptions is like GetAll but takes the pagination parameters
+// of the query.
+//
+// Equivalent to GET /consumers/{username or id}/acls?size=&offset=
+func (s *ConsumersACLService) GetAllWithOptions(consumer string, opt *ConsumerCredentialsGetAllOptions) (*ConsumerACLConfigs, *http.Response, error) {
+	return s.GetAllWithOptionsWithContext(context.Background(), consumer, opt)
+}
+
+// GetAllWithOptionsWithContext is like GetAllWithOptions but uses ctx for
+// the request.
+func (s *ConsumersACLService) GetAllWithOptionsWithContext(ctx context.Context, consumer string, opt *ConsumerCredentialsGetAllOptions) (*ConsumerACLConfigs, *http.Response, error) {
+	u, err := addOptions(fmt.Sprintf("consumers/%v/acls", consumer), opt)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	req, err := s.client.NewRequest("GET", u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -55,10 +117,106 @@ func (s *ConsumersACLService) GetAll(consumer string) (*ConsumerACLConfigs, *htt
 	return uResp, resp, err
 }
 
+// ConsumersACLService.Iterator returns an Iterator over the acls
+// of a consumer. opt.Size sets the page size and opt.Offset the starting
+// point.
+func (s *ConsumersACLService) Iterator(consumer string, opt *ConsumerCredentialsGetAllOptions) *Iterator[ConsumerACLConfig] {
+	return s.IteratorWithContext(context.Background(), consumer, opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *ConsumersACLService) IteratorWithContext(ctx context.Context, consumer string, opt *ConsumerCredentialsGetAllOptions) *Iterator[ConsumerACLConfig] {
+	o := new(ConsumerCredentialsGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+
+	return newIterator(o.Offset, func(offset string) ([]*ConsumerACLConfig, string, error) {
+		o.Offset = offset
+		configs, _, err := s.GetAllWithOptionsWithContext(ctx, consumer, o)
+		if err != nil {
+			return nil, "", err
+		}
+		return configs.Data, nextOffset(configs.Next, configs.Offset), nil
+	})
+}
+
+// ConsumersACLService.ListAll follows Kong's pagination cursor and returns
+// every acl of a consumer. If max is greater than zero no more
+// than max objects are returned.
+func (s *ConsumersACLService) ListAll(consumer string, opt *ConsumerCredentialsGetAllOptions, max int) ([]*ConsumerACLConfig, error) {
+	return s.ListAllWithContext(context.Background(), consumer, opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *ConsumersACLService) ListAllWithContext(ctx context.Context, consumer string, opt *ConsumerCredentialsGetAllOptions, max int) ([]*ConsumerACLConfig, error) {
+	return s.IteratorWithContext(ctx, consumer, opt).all(max)
+}
+
+// Get queries for a single acl of a consumer.
+//
+// Equivalent to GET /consumers/{username or id}/acls/{id}
+func (s *ConsumersACLService) Get(consumer, id string) (*ConsumerACLConfig, *http.Response, error) {
+	return s.GetWithContext(context.Background(), consumer, id)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *ConsumersACLService) GetWithContext(ctx context.Context, consumer, id string) (*ConsumerACLConfig, *http.Response, error) {
+	u := fmt.Sprintf("consumers/%v/acls/%v", consumer, id)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerACLConfig)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Patch updates an acl of a consumer in place, i.e. to change its group,
+// and returns it as stored by Kong. config.ID must be specified.
+//
+// Equivalent to PATCH /consumers/{username or id}/acls/{id}
+func (s *ConsumersACLService) Patch(consumer string, config *ConsumerACLConfig) (*ConsumerACLConfig, *http.Response, error) {
+	return s.PatchWithContext(context.Background(), consumer, config)
+}
+
+// PatchWithContext is like Patch but uses ctx for the request.
+func (s *ConsumersACLService) PatchWithContext(ctx context.Context, consumer string, config *ConsumerACLConfig) (*ConsumerACLConfig, *http.Response, error) {
+	if config.ID == "" {
+		return nil, nil, errors.New("config.ID must be specified")
+	}
+
+	u := fmt.Sprintf("consumers/%v/acls/%v", consumer, config.ID)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, u, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerACLConfig)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
 func (s *ConsumersACLService) Delete(consumer, id string) (*http.Response, error) {
+	return s.DeleteWithContext(context.Background(), consumer, id)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *ConsumersACLService) DeleteWithContext(ctx context.Context, consumer, id string) (*http.Response, error) {
 	u := fmt.Sprintf("consumers/%v/acls/%v", consumer, id)
 
-	req, err := s.client.NewRequest("DELETE", u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -71,14 +229,44 @@ func (s *ConsumersACLService) Delete(consumer, id string) (*http.Response, error
 	return resp, err
 }
 
+// Upsert creates the acl credential of consumer identified by
+// config.Group or config.ID, or updates it to match config. It reports
+// whether the credential was created, updated or already matched.
+//
+// Equivalent to PUT /consumers/{username or id}/acls, or to POST or PATCH
+// when Kong does not accept PUT
+func (s *ConsumersACLService) Upsert(consumer string, config *ConsumerACLConfig) (*ConsumerACLConfig, UpsertResult, *http.Response, error) {
+	return s.UpsertWithContext(context.Background(), consumer, config)
+}
+
+// UpsertWithContext is like Upsert but uses ctx for the requests.
+func (s *ConsumersACLService) UpsertWithContext(ctx context.Context, consumer string, config *ConsumerACLConfig) (*ConsumerACLConfig, UpsertResult, *http.Response, error) {
+	key := config.Group
+	if key == "" {
+		key = config.ID
+	}
+	if key == "" {
+		return nil, Unchanged, nil, errors.New("At least one of config.Group or config.ID must be specified")
+	}
+
+	u := fmt.Sprintf("consumers/%v/acls", consumer)
+
+	return upsert(ctx, (*service)(s), u, config, func(ctx context.Context) (*ConsumerACLConfig, *http.Response, error) {
+		return found(s.GetWithContext(ctx, consumer, key))
+	})
+}
+
 type ConsumersJWTService service
 
 type ConsumerJWTConfigs struct {
-	Data  []*ConsumerJWTConfig `json:"data,omitempty"`
-	Total int                  `json:"total,omitemtpy"`
+	Data   []*ConsumerJWTConfig `json:"data,omitempty"`
+	Total  int                  `json:"total,omitempty"`
+	Next   string               `json:"next,omitempty"`
+	Offset string               `json:"offset,omitempty"`
 }
 
 type ConsumerJWTConfig struct {
+	ConsumerID   string `json:"consumer_id,omitempty"`
 	Key          string `json:"key,omitempty"`
 	Algorithm    string `json:"algorithm,omitempty"`
 	RSAPublicKey string `json:"rsa_public_key,omitempty"`
@@ -88,9 +276,14 @@ type ConsumerJWTConfig struct {
 }
 
 func (s *ConsumersJWTService) Post(consumer string, config *ConsumerJWTConfig) (*ConsumerJWTConfig, *http.Response, error) {
+	return s.PostWithContext(context.Background(), consumer, config)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *ConsumersJWTService) PostWithContext(ctx context.Context, consumer string, config *ConsumerJWTConfig) (*ConsumerJWTConfig, *http.Response, error) {
 	u := fmt.Sprintf("consumers/%v/jwt", consumer)
 
-	req, err := s.client.NewRequest("POST", u, config)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, u, config)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -101,10 +294,35 @@ func (s *ConsumersJWTService) Post(consumer string, config *ConsumerJWTConfig) (
 	return uResp, resp, err
 }
 
+// GetAll queries for the jwt credentials of a consumer.
+//
+// Equivalent to GET /consumers/{username or id}/jwt
 func (s *ConsumersJWTService) GetAll(consumer string) (*ConsumerJWTConfigs, *http.Response, error) {
-	u := fmt.Sprintf("consumers/%v/jwt", consumer)
+	return s.GetAllWithContext(context.Background(), consumer)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *ConsumersJWTService) GetAllWithContext(ctx context.Context, consumer string) (*ConsumerJWTConfigs, *http.Response, error) {
+	return s.GetAllWithOptionsWithContext(ctx, consumer, nil)
+}
+
+// GetAllWithOptions is like GetAll but takes the pagination parameters
+// of the query.
+//
+// Equivalent to GET /consumers/{username or id}/jwt?size=&offset=
+func (s *ConsumersJWTService) GetAllWithOptions(consumer string, opt *ConsumerCredentialsGetAllOptions) (*ConsumerJWTConfigs, *http.Response, error) {
+	return s.GetAllWithOptionsWithContext(context.Background(), consumer, opt)
+}
+
+// GetAllWithOptionsWithContext is like GetAllWithOptions but uses ctx for
+// the request.
+func (s *ConsumersJWTService) GetAllWithOptionsWithContext(ctx context.Context, consumer string, opt *ConsumerCredentialsGetAllOptions) (*ConsumerJWTConfigs, *http.Response, error) {
+	u, err := addOptions(fmt.Sprintf("consumers/%v/jwt", consumer), opt)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	req, err := s.client.NewRequest("GET", u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -118,10 +336,107 @@ func (s *ConsumersJWTService) GetAll(consumer string) (*ConsumerJWTConfigs, *htt
 	return uResp, resp, err
 }
 
+// ConsumersJWTService.Iterator returns an Iterator over the jwt
+// credentials of a consumer. opt.Size sets the page size and opt.Offset the
+// starting point.
+func (s *ConsumersJWTService) Iterator(consumer string, opt *ConsumerCredentialsGetAllOptions) *Iterator[ConsumerJWTConfig] {
+	return s.IteratorWithContext(context.Background(), consumer, opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *ConsumersJWTService) IteratorWithContext(ctx context.Context, consumer string, opt *ConsumerCredentialsGetAllOptions) *Iterator[ConsumerJWTConfig] {
+	o := new(ConsumerCredentialsGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+
+	return newIterator(o.Offset, func(offset string) ([]*ConsumerJWTConfig, string, error) {
+		o.Offset = offset
+		configs, _, err := s.GetAllWithOptionsWithContext(ctx, consumer, o)
+		if err != nil {
+			return nil, "", err
+		}
+		return configs.Data, nextOffset(configs.Next, configs.Offset), nil
+	})
+}
+
+// ConsumersJWTService.ListAll follows Kong's pagination cursor and returns
+// every jwt credential of a consumer. If max is greater than zero no more
+// than max objects are returned.
+func (s *ConsumersJWTService) ListAll(consumer string, opt *ConsumerCredentialsGetAllOptions, max int) ([]*ConsumerJWTConfig, error) {
+	return s.ListAllWithContext(context.Background(), consumer, opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *ConsumersJWTService) ListAllWithContext(ctx context.Context, consumer string, opt *ConsumerCredentialsGetAllOptions, max int) ([]*ConsumerJWTConfig, error) {
+	return s.IteratorWithContext(ctx, consumer, opt).all(max)
+}
+
+// Get queries for a single jwt credential of a consumer.
+//
+// Equivalent to GET /consumers/{username or id}/jwt/{id}
+func (s *ConsumersJWTService) Get(consumer, id string) (*ConsumerJWTConfig, *http.Response, error) {
+	return s.GetWithContext(context.Background(), consumer, id)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *ConsumersJWTService) GetWithContext(ctx context.Context, consumer, id string) (*ConsumerJWTConfig, *http.Response, error) {
+	u := fmt.Sprintf("consumers/%v/jwt/%v", consumer, id)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerJWTConfig)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Patch updates a jwt credential of a consumer in place, i.e. to rotate its
+// secret or public key, and returns it as stored by Kong. config.ID must be
+// specified.
+//
+// Equivalent to PATCH /consumers/{username or id}/jwt/{id}
+func (s *ConsumersJWTService) Patch(consumer string, config *ConsumerJWTConfig) (*ConsumerJWTConfig, *http.Response, error) {
+	return s.PatchWithContext(context.Background(), consumer, config)
+}
+
+// PatchWithContext is like Patch but uses ctx for the request.
+func (s *ConsumersJWTService) PatchWithContext(ctx context.Context, consumer string, config *ConsumerJWTConfig) (*ConsumerJWTConfig, *http.Response, error) {
+	if config.ID == "" {
+		return nil, nil, errors.New("config.ID must be specified")
+	}
+
+	u := fmt.Sprintf("consumers/%v/jwt/%v", consumer, config.ID)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, u, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerJWTConfig)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
 func (s *ConsumersJWTService) Delete(consumer, id string) (*http.Response, error) {
+	return s.DeleteWithContext(context.Background(), consumer, id)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *ConsumersJWTService) DeleteWithContext(ctx context.Context, consumer, id string) (*http.Response, error) {
 	u := fmt.Sprintf("consumers/%v/jwt/%v", consumer, id)
 
-	req, err := s.client.NewRequest("DELETE", u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -134,11 +449,40 @@ func (s *ConsumersJWTService) Delete(consumer, id string) (*http.Response, error
 	return resp, err
 }
 
+// Upsert creates the jwt credential of consumer identified by
+// config.Key or config.ID, or updates it to match config. It reports
+// whether the credential was created, updated or already matched.
+//
+// Equivalent to PUT /consumers/{username or id}/jwt, or to POST or PATCH
+// when Kong does not accept PUT
+func (s *ConsumersJWTService) Upsert(consumer string, config *ConsumerJWTConfig) (*ConsumerJWTConfig, UpsertResult, *http.Response, error) {
+	return s.UpsertWithContext(context.Background(), consumer, config)
+}
+
+// UpsertWithContext is like Upsert but uses ctx for the requests.
+func (s *ConsumersJWTService) UpsertWithContext(ctx context.Context, consumer string, config *ConsumerJWTConfig) (*ConsumerJWTConfig, UpsertResult, *http.Response, error) {
+	key := config.Key
+	if key == "" {
+		key = config.ID
+	}
+	if key == "" {
+		return nil, Unchanged, nil, errors.New("At least one of config.Key or config.ID must be specified")
+	}
+
+	u := fmt.Sprintf("consumers/%v/jwt", consumer)
+
+	return upsert(ctx, (*service)(s), u, config, func(ctx context.Context) (*ConsumerJWTConfig, *http.Response, error) {
+		return found(s.GetWithContext(ctx, consumer, key))
+	})
+}
+
 type ConsumersKeyAuthService service
 
 type ConsumerKeyAuthConfigs struct {
-	Data  []*ConsumerKeyAuthConfig `json:"data,omitempty"`
-	Total int                      `json:"total,omitemtpy"`
+	Data   []*ConsumerKeyAuthConfig `json:"data,omitempty"`
+	Total  int                      `json:"total,omitempty"`
+	Next   string                   `json:"next,omitempty"`
+	Offset string                   `json:"offset,omitempty"`
 }
 
 type ConsumerKeyAuthConfig struct {
@@ -149,9 +493,14 @@ type ConsumerKeyAuthConfig struct {
 }
 
 func (s *ConsumersKeyAuthService) Post(consumer string, config *ConsumerKeyAuthConfig) (*ConsumerKeyAuthConfig, *http.Response, error) {
+	return s.PostWithContext(context.Background(), consumer, config)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *ConsumersKeyAuthService) PostWithContext(ctx context.Context, consumer string, config *ConsumerKeyAuthConfig) (*ConsumerKeyAuthConfig, *http.Response, error) {
 	u := fmt.Sprintf("consumers/%v/key-auth", consumer)
 
-	req, err := s.client.NewRequest("POST", u, config)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, u, config)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -162,10 +511,35 @@ func (s *ConsumersKeyAuthService) Post(consumer string, config *ConsumerKeyAuthC
 	return uResp, resp, err
 }
 
+// GetAll queries for the key-auth credentials of a consumer.
+//
+// Equivalent to GET /consumers/{username or id}/key-auth
 func (s *ConsumersKeyAuthService) GetAll(consumer string) (*ConsumerKeyAuthConfigs, *http.Response, error) {
-	u := fmt.Sprintf("consumers/%v/key-auth", consumer)
+	return s.GetAllWithContext(context.Background(), consumer)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *ConsumersKeyAuthService) GetAllWithContext(ctx context.Context, consumer string) (*ConsumerKeyAuthConfigs, *http.Response, error) {
+	return s.GetAllWithOptionsWithContext(ctx, consumer, nil)
+}
 
-	req, err := s.client.NewRequest("GET", u, nil)
+// GetAllWithOptions is like GetAll but takes the pagination parameters
+// of the query.
+//
+// Equivalent to GET /consumers/{username or id}/key-auth?size=&offset=
+func (s *ConsumersKeyAuthService) GetAllWithOptions(consumer string, opt *ConsumerCredentialsGetAllOptions) (*ConsumerKeyAuthConfigs, *http.Response, error) {
+	return s.GetAllWithOptionsWithContext(context.Background(), consumer, opt)
+}
+
+// GetAllWithOptionsWithContext is like GetAllWithOptions but uses ctx for
+// the request.
+func (s *ConsumersKeyAuthService) GetAllWithOptionsWithContext(ctx context.Context, consumer string, opt *ConsumerCredentialsGetAllOptions) (*ConsumerKeyAuthConfigs, *http.Response, error) {
+	u, err := addOptions(fmt.Sprintf("consumers/%v/key-auth", consumer), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -179,10 +553,106 @@ func (s *ConsumersKeyAuthService) GetAll(consumer string) (*ConsumerKeyAuthConfi
 	return uResp, resp, err
 }
 
+// ConsumersKeyAuthService.Iterator returns an Iterator over
+// the key-auth credentials of a consumer. opt.Size sets the page size and
+// opt.Offset the starting point.
+func (s *ConsumersKeyAuthService) Iterator(consumer string, opt *ConsumerCredentialsGetAllOptions) *Iterator[ConsumerKeyAuthConfig] {
+	return s.IteratorWithContext(context.Background(), consumer, opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *ConsumersKeyAuthService) IteratorWithContext(ctx context.Context, consumer string, opt *ConsumerCredentialsGetAllOptions) *Iterator[ConsumerKeyAuthConfig] {
+	o := new(ConsumerCredentialsGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+
+	return newIterator(o.Offset, func(offset string) ([]*ConsumerKeyAuthConfig, string, error) {
+		o.Offset = offset
+		configs, _, err := s.GetAllWithOptionsWithContext(ctx, consumer, o)
+		if err != nil {
+			return nil, "", err
+		}
+		return configs.Data, nextOffset(configs.Next, configs.Offset), nil
+	})
+}
+
+// ConsumersKeyAuthService.ListAll follows Kong's pagination cursor and returns
+// every key-auth credential of a consumer. If max is greater than zero no more
+// than max objects are returned.
+func (s *ConsumersKeyAuthService) ListAll(consumer string, opt *ConsumerCredentialsGetAllOptions, max int) ([]*ConsumerKeyAuthConfig, error) {
+	return s.ListAllWithContext(context.Background(), consumer, opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *ConsumersKeyAuthService) ListAllWithContext(ctx context.Context, consumer string, opt *ConsumerCredentialsGetAllOptions, max int) ([]*ConsumerKeyAuthConfig, error) {
+	return s.IteratorWithContext(ctx, consumer, opt).all(max)
+}
+
+// Get queries for a single key-auth credential of a consumer.
+//
+// Equivalent to GET /consumers/{username or id}/key-auth/{id}
+func (s *ConsumersKeyAuthService) Get(consumer, id string) (*ConsumerKeyAuthConfig, *http.Response, error) {
+	return s.GetWithContext(context.Background(), consumer, id)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *ConsumersKeyAuthService) GetWithContext(ctx context.Context, consumer, id string) (*ConsumerKeyAuthConfig, *http.Response, error) {
+	u := fmt.Sprintf("consumers/%v/key-auth/%v", consumer, id)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerKeyAuthConfig)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Patch updates a key-auth credential of a consumer in place, i.e. to rotate
+// its key, and returns it as stored by Kong. config.ID must be specified.
+//
+// Equivalent to PATCH /consumers/{username or id}/key-auth/{id}
+func (s *ConsumersKeyAuthService) Patch(consumer string, config *ConsumerKeyAuthConfig) (*ConsumerKeyAuthConfig, *http.Response, error) {
+	return s.PatchWithContext(context.Background(), consumer, config)
+}
+
+// PatchWithContext is like Patch but uses ctx for the request.
+func (s *ConsumersKeyAuthService) PatchWithContext(ctx context.Context, consumer string, config *ConsumerKeyAuthConfig) (*ConsumerKeyAuthConfig, *http.Response, error) {
+	if config.ID == "" {
+		return nil, nil, errors.New("config.ID must be specified")
+	}
+
+	u := fmt.Sprintf("consumers/%v/key-auth/%v", consumer, config.ID)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, u, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerKeyAuthConfig)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
 func (s *ConsumersKeyAuthService) Delete(consumer, id string) (*http.Response, error) {
+	return s.DeleteWithContext(context.Background(), consumer, id)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *ConsumersKeyAuthService) DeleteWithContext(ctx context.Context, consumer, id string) (*http.Response, error) {
 	u := fmt.Sprintf("consumers/%v/key-auth/%v", consumer, id)
 
-	req, err := s.client.NewRequest("DELETE", u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -194,3 +664,431 @@ func (s *ConsumersKeyAuthService) Delete(consumer, id string) (*http.Response, e
 
 	return resp, err
 }
+
+// Upsert creates the key-auth credential of consumer identified by
+// config.Key or config.ID, or updates it to match config. It reports
+// whether the credential was created, updated or already matched.
+//
+// Equivalent to PUT /consumers/{username or id}/key-auth, or to POST or PATCH
+// when Kong does not accept PUT
+func (s *ConsumersKeyAuthService) Upsert(consumer string, config *ConsumerKeyAuthConfig) (*ConsumerKeyAuthConfig, UpsertResult, *http.Response, error) {
+	return s.UpsertWithContext(context.Background(), consumer, config)
+}
+
+// UpsertWithContext is like Upsert but uses ctx for the requests.
+func (s *ConsumersKeyAuthService) UpsertWithContext(ctx context.Context, consumer string, config *ConsumerKeyAuthConfig) (*ConsumerKeyAuthConfig, UpsertResult, *http.Response, error) {
+	key := config.Key
+	if key == "" {
+		key = config.ID
+	}
+	if key == "" {
+		return nil, Unchanged, nil, errors.New("At least one of config.Key or config.ID must be specified")
+	}
+
+	u := fmt.Sprintf("consumers/%v/key-auth", consumer)
+
+	return upsert(ctx, (*service)(s), u, config, func(ctx context.Context) (*ConsumerKeyAuthConfig, *http.Response, error) {
+		return found(s.GetWithContext(ctx, consumer, key))
+	})
+}
+
+// ConsumersBasicAuthService handles communication with Kong's
+// '/consumers/{username or id}/basic-auth' resource.
+type ConsumersBasicAuthService service
+
+// ConsumerBasicAuthConfigs represents the object returned from Kong when
+// querying for the basic-auth credentials of a consumer.
+type ConsumerBasicAuthConfigs struct {
+	Data   []*ConsumerBasicAuthConfig `json:"data,omitempty"`
+	Total  int                        `json:"total,omitempty"`
+	Next   string                     `json:"next,omitempty"`
+	Offset string                     `json:"offset,omitempty"`
+}
+
+// ConsumerBasicAuthConfig represents a single basic-auth credential.
+//
+// Kong stores Password hashed, so credentials read back from Kong hold
+// the hash rather than the password they were created with.
+type ConsumerBasicAuthConfig struct {
+	ConsumerID string `json:"consumer_id,omitempty"`
+	CreatedAt  int    `json:"created_at,omitempty"`
+	ID         string `json:"id,omitempty"`
+	Username   string `json:"username,omitempty"`
+	Password   string `json:"password,omitempty"`
+}
+
+// Post creates a basic-auth credential for a consumer and returns it as
+// stored by Kong.
+//
+// Equivalent to POST /consumers/{username or id}/basic-auth
+func (s *ConsumersBasicAuthService) Post(consumer string, config *ConsumerBasicAuthConfig) (*ConsumerBasicAuthConfig, *http.Response, error) {
+	return s.PostWithContext(context.Background(), consumer, config)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *ConsumersBasicAuthService) PostWithContext(ctx context.Context, consumer string, config *ConsumerBasicAuthConfig) (*ConsumerBasicAuthConfig, *http.Response, error) {
+	u := fmt.Sprintf("consumers/%v/basic-auth", consumer)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, u, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerBasicAuthConfig)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// GetAll queries for the basic-auth credentials of a consumer.
+//
+// Equivalent to GET /consumers/{username or id}/basic-auth?uri=params&from=opt
+func (s *ConsumersBasicAuthService) GetAll(consumer string, opt *ConsumerCredentialsGetAllOptions) (*ConsumerBasicAuthConfigs, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), consumer, opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *ConsumersBasicAuthService) GetAllWithContext(ctx context.Context, consumer string, opt *ConsumerCredentialsGetAllOptions) (*ConsumerBasicAuthConfigs, *http.Response, error) {
+	u, err := addOptions(fmt.Sprintf("consumers/%v/basic-auth", consumer), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerBasicAuthConfigs)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Get queries for a single basic-auth credential of a consumer.
+//
+// Equivalent to GET /consumers/{username or id}/basic-auth/{id}
+func (s *ConsumersBasicAuthService) Get(consumer, id string) (*ConsumerBasicAuthConfig, *http.Response, error) {
+	return s.GetWithContext(context.Background(), consumer, id)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *ConsumersBasicAuthService) GetWithContext(ctx context.Context, consumer, id string) (*ConsumerBasicAuthConfig, *http.Response, error) {
+	u := fmt.Sprintf("consumers/%v/basic-auth/%v", consumer, id)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerBasicAuthConfig)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Patch updates a basic-auth credential of a consumer, i.e. to change its
+// password, and returns it as stored by Kong. config.ID must be specified.
+//
+// Equivalent to PATCH /consumers/{username or id}/basic-auth/{id}
+func (s *ConsumersBasicAuthService) Patch(consumer string, config *ConsumerBasicAuthConfig) (*ConsumerBasicAuthConfig, *http.Response, error) {
+	return s.PatchWithContext(context.Background(), consumer, config)
+}
+
+// PatchWithContext is like Patch but uses ctx for the request.
+func (s *ConsumersBasicAuthService) PatchWithContext(ctx context.Context, consumer string, config *ConsumerBasicAuthConfig) (*ConsumerBasicAuthConfig, *http.Response, error) {
+	if config.ID == "" {
+		return nil, nil, errors.New("config.ID must be specified")
+	}
+
+	u := fmt.Sprintf("consumers/%v/basic-auth/%v", consumer, config.ID)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, u, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerBasicAuthConfig)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Delete deletes a single basic-auth credential of a consumer.
+//
+// Equivalent to DELETE /consumers/{username or id}/basic-auth/{id}
+func (s *ConsumersBasicAuthService) Delete(consumer, id string) (*http.Response, error) {
+	return s.DeleteWithContext(context.Background(), consumer, id)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *ConsumersBasicAuthService) DeleteWithContext(ctx context.Context, consumer, id string) (*http.Response, error) {
+	u := fmt.Sprintf("consumers/%v/basic-auth/%v", consumer, id)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+
+	return resp, err
+}
+
+// Upsert creates the basic-auth credential of consumer identified by
+// config.Username or config.ID, or updates it to match config. It reports
+// whether the credential was created, updated or already matched.
+//
+// Kong stores passwords hashed, so a config carrying a Password is never
+// found to match and always updates the credential.
+//
+// Equivalent to PUT /consumers/{username or id}/basic-auth, or to POST or PATCH
+// when Kong does not accept PUT
+func (s *ConsumersBasicAuthService) Upsert(consumer string, config *ConsumerBasicAuthConfig) (*ConsumerBasicAuthConfig, UpsertResult, *http.Response, error) {
+	return s.UpsertWithContext(context.Background(), consumer, config)
+}
+
+// UpsertWithContext is like Upsert but uses ctx for the requests.
+func (s *ConsumersBasicAuthService) UpsertWithContext(ctx context.Context, consumer string, config *ConsumerBasicAuthConfig) (*ConsumerBasicAuthConfig, UpsertResult, *http.Response, error) {
+	key := config.Username
+	if key == "" {
+		key = config.ID
+	}
+	if key == "" {
+		return nil, Unchanged, nil, errors.New("At least one of config.Username or config.ID must be specified")
+	}
+
+	u := fmt.Sprintf("consumers/%v/basic-auth", consumer)
+
+	return upsert(ctx, (*service)(s), u, config, func(ctx context.Context) (*ConsumerBasicAuthConfig, *http.Response, error) {
+		return found(s.GetWithContext(ctx, consumer, key))
+	})
+}
+
+// ConsumersHMACAuthService handles communication with Kong's
+// '/consumers/{username or id}/hmac-auth' resource.
+type ConsumersHMACAuthService service
+
+// ConsumerHMACAuthConfigs represents the object returned from Kong when
+// querying for the hmac-auth credentials of a consumer.
+type ConsumerHMACAuthConfigs struct {
+	Data   []*ConsumerHMACAuthConfig `json:"data,omitempty"`
+	Total  int                       `json:"total,omitempty"`
+	Next   string                    `json:"next,omitempty"`
+	Offset string                    `json:"offset,omitempty"`
+}
+
+// ConsumerHMACAuthConfig represents a single hmac-auth credential. Kong
+// generates Secret when it is left empty.
+type ConsumerHMACAuthConfig struct {
+	ConsumerID string `json:"consumer_id,omitempty"`
+	CreatedAt  int    `json:"created_at,omitempty"`
+	ID         string `json:"id,omitempty"`
+	Username   string `json:"username,omitempty"`
+	Secret     string `json:"secret,omitempty"`
+}
+
+// Post creates an hmac-auth credential for a consumer and returns it as
+// stored by Kong.
+//
+// Equivalent to POST /consumers/{username or id}/hmac-auth
+func (s *ConsumersHMACAuthService) Post(consumer string, config *ConsumerHMACAuthConfig) (*ConsumerHMACAuthConfig, *http.Response, error) {
+	return s.PostWithContext(context.Background(), consumer, config)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *ConsumersHMACAuthService) PostWithContext(ctx context.Context, consumer string, config *ConsumerHMACAuthConfig) (*ConsumerHMACAuthConfig, *http.Response, error) {
+	u := fmt.Sprintf("consumers/%v/hmac-auth", consumer)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, u, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerHMACAuthConfig)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// GetAll queries for the hmac-auth credentials of a consumer.
+//
+// Equivalent to GET /consumers/{username or id}/hmac-auth?uri=params&from=opt
+func (s *ConsumersHMACAuthService) GetAll(consumer string, opt *ConsumerCredentialsGetAllOptions) (*ConsumerHMACAuthConfigs, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), consumer, opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *ConsumersHMACAuthService) GetAllWithContext(ctx context.Context, consumer string, opt *ConsumerCredentialsGetAllOptions) (*ConsumerHMACAuthConfigs, *http.Response, error) {
+	u, err := addOptions(fmt.Sprintf("consumers/%v/hmac-auth", consumer), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerHMACAuthConfigs)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// ConsumersHMACAuthService.Iterator returns an Iterator over
+// the hmac-auth credentials of a consumer. opt.Size sets the page size and
+// opt.Offset the starting point.
+func (s *ConsumersHMACAuthService) Iterator(consumer string, opt *ConsumerCredentialsGetAllOptions) *Iterator[ConsumerHMACAuthConfig] {
+	return s.IteratorWithContext(context.Background(), consumer, opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *ConsumersHMACAuthService) IteratorWithContext(ctx context.Context, consumer string, opt *ConsumerCredentialsGetAllOptions) *Iterator[ConsumerHMACAuthConfig] {
+	o := new(ConsumerCredentialsGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+
+	return newIterator(o.Offset, func(offset string) ([]*ConsumerHMACAuthConfig, string, error) {
+		o.Offset = offset
+		configs, _, err := s.GetAllWithContext(ctx, consumer, o)
+		if err != nil {
+			return nil, "", err
+		}
+		return configs.Data, nextOffset(configs.Next, configs.Offset), nil
+	})
+}
+
+// ConsumersHMACAuthService.ListAll follows Kong's pagination cursor and
+// returns every hmac-auth credential of a consumer. If max is greater than
+// zero no more than max objects are returned.
+func (s *ConsumersHMACAuthService) ListAll(consumer string, opt *ConsumerCredentialsGetAllOptions, max int) ([]*ConsumerHMACAuthConfig, error) {
+	return s.ListAllWithContext(context.Background(), consumer, opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *ConsumersHMACAuthService) ListAllWithContext(ctx context.Context, consumer string, opt *ConsumerCredentialsGetAllOptions, max int) ([]*ConsumerHMACAuthConfig, error) {
+	return s.IteratorWithContext(ctx, consumer, opt).all(max)
+}
+
+// Get queries for a single hmac-auth credential of a consumer.
+//
+// Equivalent to GET /consumers/{username or id}/hmac-auth/{username or id}
+func (s *ConsumersHMACAuthService) Get(consumer, id string) (*ConsumerHMACAuthConfig, *http.Response, error) {
+	return s.GetWithContext(context.Background(), consumer, id)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *ConsumersHMACAuthService) GetWithContext(ctx context.Context, consumer, id string) (*ConsumerHMACAuthConfig, *http.Response, error) {
+	u := fmt.Sprintf("consumers/%v/hmac-auth/%v", consumer, id)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerHMACAuthConfig)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Patch updates an hmac-auth credential of a consumer, i.e. to rotate its
+// secret, and returns it as stored by Kong. config.ID must be specified.
+//
+// Equivalent to PATCH /consumers/{username or id}/hmac-auth/{id}
+func (s *ConsumersHMACAuthService) Patch(consumer string, config *ConsumerHMACAuthConfig) (*ConsumerHMACAuthConfig, *http.Response, error) {
+	return s.PatchWithContext(context.Background(), consumer, config)
+}
+
+// PatchWithContext is like Patch but uses ctx for the request.
+func (s *ConsumersHMACAuthService) PatchWithContext(ctx context.Context, consumer string, config *ConsumerHMACAuthConfig) (*ConsumerHMACAuthConfig, *http.Response, error) {
+	if config.ID == "" {
+		return nil, nil, errors.New("config.ID must be specified")
+	}
+
+	u := fmt.Sprintf("consumers/%v/hmac-auth/%v", consumer, config.ID)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, u, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerHMACAuthConfig)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Delete deletes a single hmac-auth credential of a consumer.
+//
+// Equivalent to DELETE /consumers/{username or id}/hmac-auth/{username or id}
+func (s *ConsumersHMACAuthService) Delete(consumer, id string) (*http.Response, error) {
+	return s.DeleteWithContext(context.Background(), consumer, id)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *ConsumersHMACAuthService) DeleteWithContext(ctx context.Context, consumer, id string) (*http.Response, error) {
+	u := fmt.Sprintf("consumers/%v/hmac-auth/%v", consumer, id)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+
+	return resp, err
+}
+
+// Upsert creates the hmac-auth credential of consumer identified by
+// config.Username or config.ID, or updates it to match config. It reports
+// whether the credential was created, updated or already matched.
+//
+// Equivalent to PUT /consumers/{username or id}/hmac-auth, or to POST or PATCH
+// when Kong does not accept PUT
+func (s *ConsumersHMACAuthService) Upsert(consumer string, config *ConsumerHMACAuthConfig) (*ConsumerHMACAuthConfig, UpsertResult, *http.Response, error) {
+	return s.UpsertWithContext(context.Background(), consumer, config)
+}
+
+// UpsertWithContext is like Upsert but uses ctx for the requests.
+func (s *ConsumersHMACAuthService) UpsertWithContext(ctx context.Context, consumer string, config *ConsumerHMACAuthConfig) (*ConsumerHMACAuthConfig, UpsertResult, *http.Response, error) {
+	key := config.Username
+	if key == "" {
+		key = config.ID
+	}
+	if key == "" {
+		return nil, Unchanged, nil, errors.New("At least one of config.Username or config.ID must be specified")
+	}
+
+	u := fmt.Sprintf("consumers/%v/hmac-auth", consumer)
+
+	return upsert(ctx, (*service)(s), u, config, func(ctx context.Context) (*ConsumerHMACAuthConfig, *http.Response, error) {
+		return found(s.GetWithContext(ctx, consumer, key))
+	})
+}
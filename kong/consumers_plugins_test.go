@@ -0,0 +1,373 @@
+package kong
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestConsumersBasicAuthService_Post(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/basic-auth", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"username":"bob","password":"s3cret"}`+"\n")
+		fmt.Fprint(w, `{"id":"b1","consumer_id":"c1","username":"bob","password":"hash"}`)
+	})
+
+	credential, _, err := client.Consumers.Plugins.BasicAuth.Post("bob", &ConsumerBasicAuthConfig{Username: "bob", Password: "s3cret"})
+	if err != nil {
+		t.Errorf("BasicAuth.Post returned error: %v", err)
+	}
+
+	want := &ConsumerBasicAuthConfig{ID: "b1", ConsumerID: "c1", Username: "bob", Password: "hash"}
+	if !reflect.DeepEqual(credential, want) {
+		t.Errorf("BasicAuth.Post returned %+v, want %+v", credential, want)
+	}
+}
+
+func TestConsumersBasicAuthService_GetAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/basic-auth", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"size": "1", "offset": "o"})
+		fmt.Fprint(w, `{"total":2,"data":[{"id":"b1"}],"offset":"p"}`)
+	})
+
+	opt := &ConsumerCredentialsGetAllOptions{Size: 1, Offset: "o"}
+	credentials, _, err := client.Consumers.Plugins.BasicAuth.GetAll("bob", opt)
+	if err != nil {
+		t.Errorf("BasicAuth.GetAll returned error: %v", err)
+	}
+
+	want := &ConsumerBasicAuthConfigs{Total: 2, Data: []*ConsumerBasicAuthConfig{{ID: "b1"}}, Offset: "p"}
+	if !reflect.DeepEqual(credentials, want) {
+		t.Errorf("BasicAuth.GetAll returned %+v, want %+v", credentials, want)
+	}
+}
+
+func TestConsumersBasicAuthService_Get(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/basic-auth/b1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"b1","username":"bob"}`)
+	})
+
+	credential, _, err := client.Consumers.Plugins.BasicAuth.Get("bob", "b1")
+	if err != nil {
+		t.Errorf("BasicAuth.Get returned error: %v", err)
+	}
+
+	want := &ConsumerBasicAuthConfig{ID: "b1", Username: "bob"}
+	if !reflect.DeepEqual(credential, want) {
+		t.Errorf("BasicAuth.Get returned %+v, want %+v", credential, want)
+	}
+}
+
+func TestConsumersBasicAuthService_Get_badStatusCode(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/basic-auth/b1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not found"}`)
+	})
+
+	_, _, err := client.Consumers.Plugins.BasicAuth.Get("bob", "b1")
+	if _, ok := err.(*NotFoundError); !ok {
+		t.Errorf("BasicAuth.Get returned %v, want *NotFoundError", err)
+	}
+}
+
+func TestConsumersBasicAuthService_Patch(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	input := &ConsumerBasicAuthConfig{ID: "b1", Password: "n3w"}
+
+	mux.HandleFunc("/consumers/bob/basic-auth/b1", func(w http.ResponseWriter, r *http.Request) {
+		v := new(ConsumerBasicAuthConfig)
+		json.NewDecoder(r.Body).Decode(v)
+
+		testMethod(t, r, "PATCH")
+		if !reflect.DeepEqual(v, input) {
+			t.Errorf("Request body = %+v, want %+v", v, input)
+		}
+		fmt.Fprint(w, `{"id":"b1","username":"bob","password":"hash"}`)
+	})
+
+	credential, _, err := client.Consumers.Plugins.BasicAuth.Patch("bob", input)
+	if err != nil {
+		t.Errorf("BasicAuth.Patch returned error: %v", err)
+	}
+
+	want := &ConsumerBasicAuthConfig{ID: "b1", Username: "bob", Password: "hash"}
+	if !reflect.DeepEqual(credential, want) {
+		t.Errorf("BasicAuth.Patch returned %+v, want %+v", credential, want)
+	}
+}
+
+func TestConsumersBasicAuthService_Patch_missingID(t *testing.T) {
+	_, _, err := client.Consumers.Plugins.BasicAuth.Patch("bob", &ConsumerBasicAuthConfig{Password: "n3w"})
+	if err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestConsumersBasicAuthService_Delete(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/basic-auth/b1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Consumers.Plugins.BasicAuth.Delete("bob", "b1")
+	if err != nil {
+		t.Errorf("BasicAuth.Delete returned error: %v", err)
+	}
+}
+
+func TestConsumersHMACAuthService_Post(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/hmac-auth", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"username":"bob"}`+"\n")
+		fmt.Fprint(w, `{"id":"h1","consumer_id":"c1","username":"bob","secret":"s"}`)
+	})
+
+	credential, _, err := client.Consumers.Plugins.HMACAuth.Post("bob", &ConsumerHMACAuthConfig{Username: "bob"})
+	if err != nil {
+		t.Errorf("HMACAuth.Post returned error: %v", err)
+	}
+
+	want := &ConsumerHMACAuthConfig{ID: "h1", ConsumerID: "c1", Username: "bob", Secret: "s"}
+	if !reflect.DeepEqual(credential, want) {
+		t.Errorf("HMACAuth.Post returned %+v, want %+v", credential, want)
+	}
+}
+
+func TestConsumersHMACAuthService_ListAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/hmac-auth", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprint(w, `{"data":[{"id":"1"}],"total":2,"offset":"o"}`)
+		case "o":
+			fmt.Fprint(w, `{"data":[{"id":"2"}],"total":2}`)
+		}
+	})
+
+	credentials, err := client.Consumers.Plugins.HMACAuth.ListAll("bob", nil, 0)
+	if err != nil {
+		t.Errorf("HMACAuth.ListAll returned error: %v", err)
+	}
+
+	want := []*ConsumerHMACAuthConfig{{ID: "1"}, {ID: "2"}}
+	if !reflect.DeepEqual(credentials, want) {
+		t.Errorf("HMACAuth.ListAll returned %+v, want %+v", credentials, want)
+	}
+}
+
+func TestConsumersHMACAuthService_Get(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/hmac-auth/h1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"h1","username":"bob"}`)
+	})
+
+	credential, _, err := client.Consumers.Plugins.HMACAuth.Get("bob", "h1")
+	if err != nil {
+		t.Errorf("HMACAuth.Get returned error: %v", err)
+	}
+
+	want := &ConsumerHMACAuthConfig{ID: "h1", Username: "bob"}
+	if !reflect.DeepEqual(credential, want) {
+		t.Errorf("HMACAuth.Get returned %+v, want %+v", credential, want)
+	}
+}
+
+func TestConsumersHMACAuthService_Patch(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/hmac-auth/h1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"id":"h1","secret":"n3w"}`+"\n")
+		fmt.Fprint(w, `{"id":"h1","username":"bob","secret":"n3w"}`)
+	})
+
+	credential, _, err := client.Consumers.Plugins.HMACAuth.Patch("bob", &ConsumerHMACAuthConfig{ID: "h1", Secret: "n3w"})
+	if err != nil {
+		t.Errorf("HMACAuth.Patch returned error: %v", err)
+	}
+
+	want := &ConsumerHMACAuthConfig{ID: "h1", Username: "bob", Secret: "n3w"}
+	if !reflect.DeepEqual(credential, want) {
+		t.Errorf("HMACAuth.Patch returned %+v, want %+v", credential, want)
+	}
+}
+
+func TestConsumersHMACAuthService_Delete(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/hmac-auth/h1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Consumers.Plugins.HMACAuth.Delete("bob", "h1")
+	if err != nil {
+		t.Errorf("HMACAuth.Delete returned error: %v", err)
+	}
+}
+
+func TestConsumersACLService_Create(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/acls", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"group":"admins"}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"a1","consumer_id":"c1","group":"admins","created_at":1}`)
+	})
+
+	acl, _, err := client.Consumers.Plugins.ACL.Create("bob", &ConsumerACLConfig{Group: "admins"})
+	if err != nil {
+		t.Errorf("ACL.Create returned error: %v", err)
+	}
+
+	want := &ConsumerACLConfig{ID: "a1", ConsumerID: "c1", Group: "admins", CreatedAt: 1}
+	if !reflect.DeepEqual(acl, want) {
+		t.Errorf("ACL.Create returned %+v, want %+v", acl, want)
+	}
+}
+
+func TestConsumersACLService_Get(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/acls/a1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"a1","group":"admins"}`)
+	})
+
+	acl, _, err := client.Consumers.Plugins.ACL.Get("bob", "a1")
+	if err != nil {
+		t.Errorf("ACL.Get returned error: %v", err)
+	}
+
+	want := &ConsumerACLConfig{ID: "a1", Group: "admins"}
+	if !reflect.DeepEqual(acl, want) {
+		t.Errorf("ACL.Get returned %+v, want %+v", acl, want)
+	}
+}
+
+func TestConsumersACLService_Patch(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/acls/a1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"group":"users","id":"a1"}`+"\n")
+		fmt.Fprint(w, `{"id":"a1","group":"users"}`)
+	})
+
+	acl, _, err := client.Consumers.Plugins.ACL.Patch("bob", &ConsumerACLConfig{ID: "a1", Group: "users"})
+	if err != nil {
+		t.Errorf("ACL.Patch returned error: %v", err)
+	}
+
+	want := &ConsumerACLConfig{ID: "a1", Group: "users"}
+	if !reflect.DeepEqual(acl, want) {
+		t.Errorf("ACL.Patch returned %+v, want %+v", acl, want)
+	}
+}
+
+func TestConsumersJWTService_Patch(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/jwt/j1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"secret":"n3w","id":"j1"}`+"\n")
+		fmt.Fprint(w, `{"id":"j1","key":"iss","secret":"n3w","consumer_id":"c1"}`)
+	})
+
+	jwt, _, err := client.Consumers.Plugins.JWT.Patch("bob", &ConsumerJWTConfig{ID: "j1", Secret: "n3w"})
+	if err != nil {
+		t.Errorf("JWT.Patch returned error: %v", err)
+	}
+
+	want := &ConsumerJWTConfig{ID: "j1", Key: "iss", Secret: "n3w", ConsumerID: "c1"}
+	if !reflect.DeepEqual(jwt, want) {
+		t.Errorf("JWT.Patch returned %+v, want %+v", jwt, want)
+	}
+
+	if _, _, err := client.Consumers.Plugins.JWT.Patch("bob", &ConsumerJWTConfig{Secret: "n3w"}); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestConsumersKeyAuthService_GetAllWithOptions(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/key-auth", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"size": "100"})
+		fmt.Fprint(w, `{"total":150,"data":[{"id":"k1"}],"next":"http://localhost:8001/consumers/bob/key-auth?offset=o"}`)
+	})
+
+	keys, _, err := client.Consumers.Plugins.KeyAuth.GetAllWithOptions("bob", &ConsumerCredentialsGetAllOptions{Size: 100})
+	if err != nil {
+		t.Errorf("KeyAuth.GetAllWithOptions returned error: %v", err)
+	}
+
+	want := &ConsumerKeyAuthConfigs{Total: 150, Data: []*ConsumerKeyAuthConfig{{ID: "k1"}}, Next: "http://localhost:8001/consumers/bob/key-auth?offset=o"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("KeyAuth.GetAllWithOptions returned %+v, want %+v", keys, want)
+	}
+}
+
+func TestConsumersKeyAuthService_ListAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/key-auth", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprint(w, `{"data":[{"id":"1"}],"total":3,"offset":"o"}`)
+		case "o":
+			fmt.Fprint(w, `{"data":[{"id":"2"},{"id":"3"}],"total":3}`)
+		}
+	})
+
+	keys, err := client.Consumers.Plugins.KeyAuth.ListAll("bob", nil, 2)
+	if err != nil {
+		t.Errorf("KeyAuth.ListAll returned error: %v", err)
+	}
+
+	want := []*ConsumerKeyAuthConfig{{ID: "1"}, {ID: "2"}}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("KeyAuth.ListAll returned %+v, want %+v", keys, want)
+	}
+}
@@ -1,6 +1,7 @@
 package kong
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -199,6 +200,52 @@ func TestConsumersService_Post_badStatusCode(t *testing.T) {
 	}
 }
 
+func TestConsumersService_Create(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"username":"u"}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"i","username":"u","created_at":1}`)
+	})
+
+	consumer, _, err := client.Consumers.Create(&Consumer{Username: "u"})
+	if err != nil {
+		t.Errorf("Consumers.Create returned error: %v", err)
+	}
+
+	want := &Consumer{ID: "i", Username: "u", CreatedAt: 1}
+	if !reflect.DeepEqual(consumer, want) {
+		t.Errorf("Consumers.Create returned %+v, want %+v", consumer, want)
+	}
+}
+
+func TestConsumersService_Update(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/i", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		fmt.Fprint(w, `{"id":"i","username":"u","custom_id":"c"}`)
+	})
+
+	consumer, _, err := client.Consumers.Update(&Consumer{ID: "i", CustomID: "c"})
+	if err != nil {
+		t.Errorf("Consumers.Update returned error: %v", err)
+	}
+
+	want := &Consumer{ID: "i", Username: "u", CustomID: "c"}
+	if !reflect.DeepEqual(consumer, want) {
+		t.Errorf("Consumers.Update returned %+v, want %+v", consumer, want)
+	}
+
+	if _, _, err := client.Consumers.Update(&Consumer{}); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
 func TestConsumersService_GetAll(t *testing.T) {
 	stubSetup()
 	defer stubTeardown()
@@ -237,3 +284,45 @@ func TestConsumersService_GetAll_badStatusCode(t *testing.T) {
 		t.Error("Expected error to be returned")
 	}
 }
+
+func TestConsumersService_GetWithContext_canceled(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/i", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Request should not have reached the server")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := client.Consumers.GetWithContext(ctx, "i")
+	if err != context.Canceled {
+		t.Errorf("Consumers.GetWithContext returned error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestConsumersService_ListAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprint(w, `{"data":[{"id":"1"}],"total":2,"next":"http://kong/consumers?size=1&offset=o"}`)
+		case "o":
+			fmt.Fprint(w, `{"data":[{"id":"2"}],"total":2}`)
+		}
+	})
+
+	consumers, err := client.Consumers.ListAll(&ConsumersGetAllOptions{Size: 1}, 0)
+	if err != nil {
+		t.Errorf("Consumers.ListAll returned error: %v", err)
+	}
+
+	want := []*Consumer{{ID: "1"}, {ID: "2"}}
+	if !reflect.DeepEqual(consumers, want) {
+		t.Errorf("Consumers.ListAll returned %+v, want %+v", consumers, want)
+	}
+}
@@ -0,0 +1,535 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// KeyAuthsService handles communication with Kong's '/key-auths' resource,
+// which lists the key-auth credentials of every consumer.
+type KeyAuthsService struct {
+	*service
+}
+
+// KeyAuthsGetAllOptions specifies optional filter parameters to the
+// KeyAuthsService.GetAll method.
+type KeyAuthsGetAllOptions struct {
+	ID         string `url:"id,omitempty"`          // A filter on the list based on the id field.
+	Key        string `url:"key,omitempty"`         // A filter on the list based on the key field.
+	ConsumerID string `url:"consumer_id,omitempty"` // A filter on the list based on the consumer_id field.
+	Size       int    `url:"size,omitempty"`        // A limit on the number of objects to be returned.
+	Offset     string `url:"offset,omitempty"`      // A cursor used for pagination. offset is an object identifier that defines a place in the list.
+}
+
+// Get queries for a single key-auth credential, by id or by its key.
+//
+// Equivalent to GET /key-auths/{key or id}
+func (s *KeyAuthsService) Get(credential string) (*ConsumerKeyAuthConfig, *http.Response, error) {
+	return s.GetWithContext(context.Background(), credential)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *KeyAuthsService) GetWithContext(ctx context.Context, credential string) (*ConsumerKeyAuthConfig, *http.Response, error) {
+	u := fmt.Sprintf("key-auths/%v", credential)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerKeyAuthConfig)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// GetConsumer queries for the consumer owning a single key-auth credential,
+// by id or by its key, i.e. to find out who owns an API key.
+//
+// Equivalent to GET /key-auths/{key or id}/consumer
+func (s *KeyAuthsService) GetConsumer(credential string) (*Consumer, *http.Response, error) {
+	return s.GetConsumerWithContext(context.Background(), credential)
+}
+
+// GetConsumerWithContext is like GetConsumer but uses ctx for the request.
+func (s *KeyAuthsService) GetConsumerWithContext(ctx context.Context, credential string) (*Consumer, *http.Response, error) {
+	u := fmt.Sprintf("key-auths/%v/consumer", credential)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Consumer)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// GetAll queries for the key-auth credentials of all consumers.
+// This query can be filtered by supplying the KeyAuthsGetAllOptions struct.
+//
+// Equivalent to GET /key-auths?uri=params&from=opt
+func (s *KeyAuthsService) GetAll(opt *KeyAuthsGetAllOptions) (*ConsumerKeyAuthConfigs, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *KeyAuthsService) GetAllWithContext(ctx context.Context, opt *KeyAuthsGetAllOptions) (*ConsumerKeyAuthConfigs, *http.Response, error) {
+	u, err := addOptions("key-auths", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerKeyAuthConfigs)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// KeyAuthsService.Iterator returns an Iterator over the key-auth
+// credentials of all consumers. opt.Size sets the page size and opt.Offset
+// the starting point, the remaining fields filter the results as in GetAll.
+func (s *KeyAuthsService) Iterator(opt *KeyAuthsGetAllOptions) *Iterator[ConsumerKeyAuthConfig] {
+	return s.IteratorWithContext(context.Background(), opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *KeyAuthsService) IteratorWithContext(ctx context.Context, opt *KeyAuthsGetAllOptions) *Iterator[ConsumerKeyAuthConfig] {
+	o := new(KeyAuthsGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+
+	return newIterator(o.Offset, func(offset string) ([]*ConsumerKeyAuthConfig, string, error) {
+		o.Offset = offset
+		configs, _, err := s.GetAllWithContext(ctx, o)
+		if err != nil {
+			return nil, "", err
+		}
+		return configs.Data, nextOffset(configs.Next, configs.Offset), nil
+	})
+}
+
+// KeyAuthsService.ListAll follows Kong's pagination cursor and returns every
+// key-auth credential matching opt. If max is greater than zero no more than
+// max objects are returned.
+func (s *KeyAuthsService) ListAll(opt *KeyAuthsGetAllOptions, max int) ([]*ConsumerKeyAuthConfig, error) {
+	return s.ListAllWithContext(context.Background(), opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *KeyAuthsService) ListAllWithContext(ctx context.Context, opt *KeyAuthsGetAllOptions, max int) ([]*ConsumerKeyAuthConfig, error) {
+	return s.IteratorWithContext(ctx, opt).all(max)
+}
+
+// JWTsService handles communication with Kong's '/jwts' resource,
+// which lists the jwt credentials of every consumer.
+type JWTsService struct {
+	*service
+}
+
+// JWTsGetAllOptions specifies optional filter parameters to the
+// JWTsService.GetAll method.
+type JWTsGetAllOptions struct {
+	ID         string `url:"id,omitempty"`          // A filter on the list based on the id field.
+	Key        string `url:"key,omitempty"`         // A filter on the list based on the key field.
+	ConsumerID string `url:"consumer_id,omitempty"` // A filter on the list based on the consumer_id field.
+	Size       int    `url:"size,omitempty"`        // A limit on the number of objects to be returned.
+	Offset     string `url:"offset,omitempty"`      // A cursor used for pagination. offset is an object identifier that defines a place in the list.
+}
+
+// Get queries for a single jwt credential, by id or by its key.
+//
+// Equivalent to GET /jwts/{key or id}
+func (s *JWTsService) Get(credential string) (*ConsumerJWTConfig, *http.Response, error) {
+	return s.GetWithContext(context.Background(), credential)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *JWTsService) GetWithContext(ctx context.Context, credential string) (*ConsumerJWTConfig, *http.Response, error) {
+	u := fmt.Sprintf("jwts/%v", credential)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerJWTConfig)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// GetConsumer queries for the consumer owning a single jwt credential, by id
+// or by its key, i.e. by the iss claim of a token.
+//
+// Equivalent to GET /jwts/{key or id}/consumer
+func (s *JWTsService) GetConsumer(credential string) (*Consumer, *http.Response, error) {
+	return s.GetConsumerWithContext(context.Background(), credential)
+}
+
+// GetConsumerWithContext is like GetConsumer but uses ctx for the request.
+func (s *JWTsService) GetConsumerWithContext(ctx context.Context, credential string) (*Consumer, *http.Response, error) {
+	u := fmt.Sprintf("jwts/%v/consumer", credential)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Consumer)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// GetAll queries for the jwt credentials of all consumers.
+// This query can be filtered by supplying the JWTsGetAllOptions struct.
+//
+// Equivalent to GET /jwts?uri=params&from=opt
+func (s *JWTsService) GetAll(opt *JWTsGetAllOptions) (*ConsumerJWTConfigs, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *JWTsService) GetAllWithContext(ctx context.Context, opt *JWTsGetAllOptions) (*ConsumerJWTConfigs, *http.Response, error) {
+	u, err := addOptions("jwts", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerJWTConfigs)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// JWTsService.Iterator returns an Iterator over the jwt credentials of
+// all consumers. opt.Size sets the page size and opt.Offset the starting
+// point, the remaining fields filter the results as in GetAll.
+func (s *JWTsService) Iterator(opt *JWTsGetAllOptions) *Iterator[ConsumerJWTConfig] {
+	return s.IteratorWithContext(context.Background(), opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *JWTsService) IteratorWithContext(ctx context.Context, opt *JWTsGetAllOptions) *Iterator[ConsumerJWTConfig] {
+	o := new(JWTsGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+
+	return newIterator(o.Offset, func(offset string) ([]*ConsumerJWTConfig, string, error) {
+		o.Offset = offset
+		configs, _, err := s.GetAllWithContext(ctx, o)
+		if err != nil {
+			return nil, "", err
+		}
+		return configs.Data, nextOffset(configs.Next, configs.Offset), nil
+	})
+}
+
+// JWTsService.ListAll follows Kong's pagination cursor and returns every
+// jwt credential matching opt. If max is greater than zero no more than max
+// objects are returned.
+func (s *JWTsService) ListAll(opt *JWTsGetAllOptions, max int) ([]*ConsumerJWTConfig, error) {
+	return s.ListAllWithContext(context.Background(), opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *JWTsService) ListAllWithContext(ctx context.Context, opt *JWTsGetAllOptions, max int) ([]*ConsumerJWTConfig, error) {
+	return s.IteratorWithContext(ctx, opt).all(max)
+}
+
+// ACLsService handles communication with Kong's '/acls' resource,
+// which lists the acls of every consumer.
+type ACLsService struct {
+	*service
+}
+
+// ACLsGetAllOptions specifies optional filter parameters to the
+// ACLsService.GetAll method.
+type ACLsGetAllOptions struct {
+	ID         string `url:"id,omitempty"`          // A filter on the list based on the id field.
+	Group      string `url:"group,omitempty"`       // A filter on the list based on the group field.
+	ConsumerID string `url:"consumer_id,omitempty"` // A filter on the list based on the consumer_id field.
+	Size       int    `url:"size,omitempty"`        // A limit on the number of objects to be returned.
+	Offset     string `url:"offset,omitempty"`      // A cursor used for pagination. offset is an object identifier that defines a place in the list.
+}
+
+// Get queries for a single acl, by id.
+//
+// Equivalent to GET /acls/{id}
+func (s *ACLsService) Get(credential string) (*ConsumerACLConfig, *http.Response, error) {
+	return s.GetWithContext(context.Background(), credential)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *ACLsService) GetWithContext(ctx context.Context, credential string) (*ConsumerACLConfig, *http.Response, error) {
+	u := fmt.Sprintf("acls/%v", credential)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerACLConfig)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// GetConsumer queries for the consumer owning a single acl, by id.
+//
+// Equivalent to GET /acls/{id}/consumer
+func (s *ACLsService) GetConsumer(credential string) (*Consumer, *http.Response, error) {
+	return s.GetConsumerWithContext(context.Background(), credential)
+}
+
+// GetConsumerWithContext is like GetConsumer but uses ctx for the request.
+func (s *ACLsService) GetConsumerWithContext(ctx context.Context, credential string) (*Consumer, *http.Response, error) {
+	u := fmt.Sprintf("acls/%v/consumer", credential)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Consumer)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// GetAll queries for the acls of all consumers.
+// This query can be filtered by supplying the ACLsGetAllOptions struct.
+//
+// Equivalent to GET /acls?uri=params&from=opt
+func (s *ACLsService) GetAll(opt *ACLsGetAllOptions) (*ConsumerACLConfigs, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *ACLsService) GetAllWithContext(ctx context.Context, opt *ACLsGetAllOptions) (*ConsumerACLConfigs, *http.Response, error) {
+	u, err := addOptions("acls", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerACLConfigs)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// ACLsService.Iterator returns an Iterator over the acls of
+// all consumers. opt.Size sets the page size and opt.Offset the starting
+// point, the remaining fields filter the results as in GetAll.
+func (s *ACLsService) Iterator(opt *ACLsGetAllOptions) *Iterator[ConsumerACLConfig] {
+	return s.IteratorWithContext(context.Background(), opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *ACLsService) IteratorWithContext(ctx context.Context, opt *ACLsGetAllOptions) *Iterator[ConsumerACLConfig] {
+	o := new(ACLsGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+
+	return newIterator(o.Offset, func(offset string) ([]*ConsumerACLConfig, string, error) {
+		o.Offset = offset
+		configs, _, err := s.GetAllWithContext(ctx, o)
+		if err != nil {
+			return nil, "", err
+		}
+		return configs.Data, nextOffset(configs.Next, configs.Offset), nil
+	})
+}
+
+// ACLsService.ListAll follows Kong's pagination cursor and returns every
+// acl matching opt. If max is greater than zero no more than max
+// objects are returned.
+func (s *ACLsService) ListAll(opt *ACLsGetAllOptions, max int) ([]*ConsumerACLConfig, error) {
+	return s.ListAllWithContext(context.Background(), opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *ACLsService) ListAllWithContext(ctx context.Context, opt *ACLsGetAllOptions, max int) ([]*ConsumerACLConfig, error) {
+	return s.IteratorWithContext(ctx, opt).all(max)
+}
+
+// BasicAuthsService handles communication with Kong's '/basic-auths'
+// resource, which lists the basic-auth credentials of every consumer.
+type BasicAuthsService struct {
+	*service
+}
+
+// BasicAuthsGetAllOptions specifies optional filter parameters to the
+// BasicAuthsService.GetAll method.
+type BasicAuthsGetAllOptions struct {
+	ID         string `url:"id,omitempty"`          // A filter on the list based on the id field.
+	Username   string `url:"username,omitempty"`    // A filter on the list based on the username field.
+	ConsumerID string `url:"consumer_id,omitempty"` // A filter on the list based on the consumer_id field.
+	Size       int    `url:"size,omitempty"`        // A limit on the number of objects to be returned.
+	Offset     string `url:"offset,omitempty"`      // A cursor used for pagination. offset is an object identifier that defines a place in the list.
+}
+
+// Get queries for a single basic-auth credential, by id or by its username.
+//
+// Equivalent to GET /basic-auths/{username or id}
+func (s *BasicAuthsService) Get(credential string) (*ConsumerBasicAuthConfig, *http.Response, error) {
+	return s.GetWithContext(context.Background(), credential)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *BasicAuthsService) GetWithContext(ctx context.Context, credential string) (*ConsumerBasicAuthConfig, *http.Response, error) {
+	u := fmt.Sprintf("basic-auths/%v", credential)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerBasicAuthConfig)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// GetConsumer queries for the consumer owning a single basic-auth
+// credential, by id or by its username.
+//
+// Equivalent to GET /basic-auths/{username or id}/consumer
+func (s *BasicAuthsService) GetConsumer(credential string) (*Consumer, *http.Response, error) {
+	return s.GetConsumerWithContext(context.Background(), credential)
+}
+
+// GetConsumerWithContext is like GetConsumer but uses ctx for the request.
+func (s *BasicAuthsService) GetConsumerWithContext(ctx context.Context, credential string) (*Consumer, *http.Response, error) {
+	u := fmt.Sprintf("basic-auths/%v/consumer", credential)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Consumer)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// GetAll queries for the basic-auth credentials of all consumers.
+// This query can be filtered by supplying the BasicAuthsGetAllOptions struct.
+//
+// Equivalent to GET /basic-auths?uri=params&from=opt
+func (s *BasicAuthsService) GetAll(opt *BasicAuthsGetAllOptions) (*ConsumerBasicAuthConfigs, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *BasicAuthsService) GetAllWithContext(ctx context.Context, opt *BasicAuthsGetAllOptions) (*ConsumerBasicAuthConfigs, *http.Response, error) {
+	u, err := addOptions("basic-auths", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerBasicAuthConfigs)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// BasicAuthsService.Iterator returns an Iterator over the basic-
+// auth credentials of all consumers. opt.Size sets the page size and
+// opt.Offset the starting point, the remaining fields filter the results as
+// in GetAll.
+func (s *BasicAuthsService) Iterator(opt *BasicAuthsGetAllOptions) *Iterator[ConsumerBasicAuthConfig] {
+	return s.IteratorWithContext(context.Background(), opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *BasicAuthsService) IteratorWithContext(ctx context.Context, opt *BasicAuthsGetAllOptions) *Iterator[ConsumerBasicAuthConfig] {
+	o := new(BasicAuthsGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+
+	return newIterator(o.Offset, func(offset string) ([]*ConsumerBasicAuthConfig, string, error) {
+		o.Offset = offset
+		configs, _, err := s.GetAllWithContext(ctx, o)
+		if err != nil {
+			return nil, "", err
+		}
+		return configs.Data, nextOffset(configs.Next, configs.Offset), nil
+	})
+}
+
+// BasicAuthsService.ListAll follows Kong's pagination cursor and returns
+// every basic-auth credential matching opt. If max is greater than zero no
+// more than max objects are returned.
+func (s *BasicAuthsService) ListAll(opt *BasicAuthsGetAllOptions, max int) ([]*ConsumerBasicAuthConfig, error) {
+	return s.ListAllWithContext(context.Background(), opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *BasicAuthsService) ListAllWithContext(ctx context.Context, opt *BasicAuthsGetAllOptions, max int) ([]*ConsumerBasicAuthConfig, error) {
+	return s.IteratorWithContext(ctx, opt).all(max)
+}
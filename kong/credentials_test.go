@@ -0,0 +1,173 @@
+package kong
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestKeyAuthsService_Get(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/key-auths/secret", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"k1","key":"secret","consumer_id":"c1"}`)
+	})
+
+	credential, _, err := client.KeyAuths.Get("secret")
+	if err != nil {
+		t.Errorf("KeyAuths.Get returned error: %v", err)
+	}
+
+	want := &ConsumerKeyAuthConfig{ID: "k1", Key: "secret", ConsumerID: "c1"}
+	if !reflect.DeepEqual(credential, want) {
+		t.Errorf("KeyAuths.Get returned %+v, want %+v", credential, want)
+	}
+}
+
+func TestKeyAuthsService_GetConsumer(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/key-auths/secret/consumer", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"c1","username":"bob"}`)
+	})
+
+	consumer, _, err := client.KeyAuths.GetConsumer("secret")
+	if err != nil {
+		t.Errorf("KeyAuths.GetConsumer returned error: %v", err)
+	}
+
+	want := &Consumer{ID: "c1", Username: "bob"}
+	if !reflect.DeepEqual(consumer, want) {
+		t.Errorf("KeyAuths.GetConsumer returned %+v, want %+v", consumer, want)
+	}
+}
+
+func TestKeyAuthsService_GetConsumer_notFound(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/key-auths/missing/consumer", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not found"}`)
+	})
+
+	_, _, err := client.KeyAuths.GetConsumer("missing")
+	if _, ok := err.(*NotFoundError); !ok {
+		t.Errorf("KeyAuths.GetConsumer returned %v, want *NotFoundError", err)
+	}
+}
+
+func TestKeyAuthsService_GetAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/key-auths", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"key": "secret", "size": "1"})
+		fmt.Fprint(w, `{"total":1,"data":[{"id":"k1","key":"secret"}],"offset":"o"}`)
+	})
+
+	credentials, _, err := client.KeyAuths.GetAll(&KeyAuthsGetAllOptions{Key: "secret", Size: 1})
+	if err != nil {
+		t.Errorf("KeyAuths.GetAll returned error: %v", err)
+	}
+
+	want := &ConsumerKeyAuthConfigs{Total: 1, Data: []*ConsumerKeyAuthConfig{{ID: "k1", Key: "secret"}}, Offset: "o"}
+	if !reflect.DeepEqual(credentials, want) {
+		t.Errorf("KeyAuths.GetAll returned %+v, want %+v", credentials, want)
+	}
+}
+
+func TestJWTsService_ListAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/jwts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.URL.Query().Get("consumer_id"); got != "c1" {
+			t.Errorf("Request consumer_id = %q, want c1", got)
+		}
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprint(w, `{"data":[{"id":"1"}],"total":2,"offset":"o"}`)
+		case "o":
+			fmt.Fprint(w, `{"data":[{"id":"2"}],"total":2}`)
+		}
+	})
+
+	credentials, err := client.JWTs.ListAll(&JWTsGetAllOptions{ConsumerID: "c1"}, 0)
+	if err != nil {
+		t.Errorf("JWTs.ListAll returned error: %v", err)
+	}
+
+	want := []*ConsumerJWTConfig{{ID: "1"}, {ID: "2"}}
+	if !reflect.DeepEqual(credentials, want) {
+		t.Errorf("JWTs.ListAll returned %+v, want %+v", credentials, want)
+	}
+}
+
+func TestJWTsService_GetConsumer(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/jwts/iss/consumer", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"c1"}`)
+	})
+
+	consumer, _, err := client.JWTs.GetConsumer("iss")
+	if err != nil {
+		t.Errorf("JWTs.GetConsumer returned error: %v", err)
+	}
+
+	want := &Consumer{ID: "c1"}
+	if !reflect.DeepEqual(consumer, want) {
+		t.Errorf("JWTs.GetConsumer returned %+v, want %+v", consumer, want)
+	}
+}
+
+func TestACLsService_GetAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/acls", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"group": "admins"})
+		fmt.Fprint(w, `{"total":1,"data":[{"id":"a1","group":"admins","consumer_id":"c1"}]}`)
+	})
+
+	acls, _, err := client.ACLs.GetAll(&ACLsGetAllOptions{Group: "admins"})
+	if err != nil {
+		t.Errorf("ACLs.GetAll returned error: %v", err)
+	}
+
+	want := &ConsumerACLConfigs{Total: 1, Data: []*ConsumerACLConfig{{ID: "a1", Group: "admins", ConsumerID: "c1"}}}
+	if !reflect.DeepEqual(acls, want) {
+		t.Errorf("ACLs.GetAll returned %+v, want %+v", acls, want)
+	}
+}
+
+func TestBasicAuthsService_GetConsumer(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/basic-auths/bob/consumer", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"c1","username":"bob"}`)
+	})
+
+	consumer, _, err := client.BasicAuths.GetConsumer("bob")
+	if err != nil {
+		t.Errorf("BasicAuths.GetConsumer returned error: %v", err)
+	}
+
+	want := &Consumer{ID: "c1", Username: "bob"}
+	if !reflect.DeepEqual(consumer, want) {
+		t.Errorf("BasicAuths.GetConsumer returned %+v, want %+v", consumer, want)
+	}
+}
@@ -0,0 +1,136 @@
+package kong
+
+import (
+	"net/url"
+)
+
+// Iterator steps through the objects of a Kong listing, i.e. every api
+// object matching an ApisGetAllOptions query, fetching further pages
+// from Kong as needed. Iterators are returned from the various
+// Service.Iterator methods.
+//
+//	it := client.Apis.Iterator(nil)
+//	for it.Next() {
+//		log.Println(it.Value().Name)
+//	}
+//	if err := it.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+type Iterator[T any] struct {
+	pageIterator
+	page []*T
+}
+
+// newIterator returns an Iterator starting at offset, which loads pages
+// through fetch. fetch returns the objects of the page starting at offset
+// along with the offset of the following page, empty for the last one.
+func newIterator[T any](offset string, fetch func(offset string) ([]*T, string, error)) *Iterator[T] {
+	it := &Iterator[T]{pageIterator: newPageIterator(offset)}
+	it.fetch = func(offset string) (int, string, error) {
+		page, next, err := fetch(offset)
+		if err != nil {
+			return 0, "", err
+		}
+		it.page = page
+		return len(page), next, nil
+	}
+
+	return it
+}
+
+// Value returns the object the iterator currently points at.
+func (it *Iterator[T]) Value() *T {
+	return it.page[it.index]
+}
+
+// all drains the iterator, stopping early once max objects have been
+// collected when max is greater than zero. An empty listing gives a nil
+// slice, so it matches one decoded from a document leaving it out.
+func (it *Iterator[T]) all(max int) ([]*T, error) {
+	it.limit = max
+
+	var objects []*T
+	for it.Next() {
+		objects = append(objects, it.Value())
+	}
+
+	return objects, it.Err()
+}
+
+// pageIterator holds the paging state of an Iterator. Its fetch function
+// loads the page starting at offset and reports the page length along
+// with the offset of the following page. An empty offset marks the last
+// page.
+type pageIterator struct {
+	fetch func(offset string) (n int, next string, err error)
+
+	offset string // Offset of the next page to fetch
+	last   bool   // Set once the final page has been fetched
+	size   int    // Number of objects in the current page
+	index  int    // Position of the current object in the current page
+	seen   int    // Number of objects returned so far
+	limit  int    // Maximum number of objects to return, 0 means no limit
+	err    error
+}
+
+func newPageIterator(offset string) pageIterator {
+	return pageIterator{offset: offset, index: -1}
+}
+
+// Next advances the iterator to the next object, fetching the following
+// page from Kong when the current one has been used up. It returns false
+// once every object has been returned, the limit has been reached or an
+// error occurred. Err should be checked after Next returns false.
+func (it *pageIterator) Next() bool {
+	if it.err != nil || (it.limit > 0 && it.seen >= it.limit) {
+		return false
+	}
+
+	it.index++
+	for it.index >= it.size {
+		if it.last {
+			return false
+		}
+
+		n, next, err := it.fetch(it.offset)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		// Guard against looping forever should Kong hand back the
+		// offset we just asked for
+		if next == "" || next == it.offset {
+			it.last = true
+		}
+
+		it.offset, it.size, it.index = next, n, 0
+	}
+
+	it.seen++
+	return true
+}
+
+// Err returns the first error encountered while fetching pages, if any.
+func (it *pageIterator) Err() error {
+	return it.err
+}
+
+// nextOffset determines the offset of the following page from the
+// next and offset fields of a Kong list response. Older Kong versions
+// only return the next URI, in which case the offset is taken from its
+// query string.
+//
+// i.e. "http://localhost:8001/apis/?size=2&offset=4d924084" -> "4d924084"
+func nextOffset(next, offset string) string {
+	if offset != "" || next == "" {
+		return offset
+	}
+
+	u, err := url.Parse(next)
+	if err != nil {
+		return ""
+	}
+
+	return u.Query().Get("offset")
+}
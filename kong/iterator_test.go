@@ -0,0 +1,122 @@
+package kong
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNextOffset(t *testing.T) {
+	cases := []struct {
+		next, offset, want string
+	}{
+		{"", "", ""},
+		{"", "o", "o"},
+		{"http://localhost:8001/apis/?size=2&offset=n", "o", "o"},
+		{"http://localhost:8001/apis/?size=2&offset=n", "", "n"},
+		{"http://localhost:8001/apis/?size=2", "", ""},
+		{"%", "", ""},
+	}
+
+	for _, c := range cases {
+		if got := nextOffset(c.next, c.offset); got != c.want {
+			t.Errorf("nextOffset(%q, %q) returned %q, want %q", c.next, c.offset, got, c.want)
+		}
+	}
+}
+
+// intIterator pages through pages, using the page index as the offset.
+func intIterator(pages [][]int) *Iterator[int] {
+	return newIterator("", func(offset string) ([]*int, string, error) {
+		i := 0
+		if offset != "" {
+			i = int(offset[0] - '0')
+		}
+
+		page := make([]*int, len(pages[i]))
+		for j := range pages[i] {
+			page[j] = &pages[i][j]
+		}
+
+		next := ""
+		if i+1 < len(pages) {
+			next = string(rune('0' + i + 1))
+		}
+		return page, next, nil
+	})
+}
+
+func intValues(it *Iterator[int]) []int {
+	var got []int
+	for it.Next() {
+		got = append(got, *it.Value())
+	}
+	return got
+}
+
+func TestIterator(t *testing.T) {
+	it := intIterator([][]int{{1, 2}, {}, {3}, {4, 5}})
+
+	want := []int{1, 2, 3, 4, 5}
+	if got := intValues(it); !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterator returned %v, want %v", got, want)
+	}
+	if it.Next() {
+		t.Error("Iterator.Next returned true after the last page")
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Iterator.Err returned %v", err)
+	}
+}
+
+func TestIterator_all(t *testing.T) {
+	got, err := intIterator([][]int{{1, 2}, {3, 4}}).all(3)
+	if err != nil {
+		t.Errorf("Iterator.all returned error: %v", err)
+	}
+
+	want := []int{1, 2, 3}
+	if len(got) != len(want) {
+		t.Fatalf("Iterator.all returned %d objects, want %v", len(got), want)
+	}
+	for i, v := range got {
+		if *v != want[i] {
+			t.Errorf("Iterator.all returned %v at %d, want %v", *v, i, want[i])
+		}
+	}
+}
+
+func TestIterator_allEmpty(t *testing.T) {
+	got, err := intIterator([][]int{{}}).all(0)
+	if err != nil || got != nil {
+		t.Errorf("Iterator.all returned %#v, %v, want nil", got, err)
+	}
+}
+
+func TestIterator_repeatedOffset(t *testing.T) {
+	calls := 0
+	it := newIterator("o", func(offset string) ([]*int, string, error) {
+		calls++
+		v := calls
+		return []*int{&v}, "o", nil
+	})
+
+	want := []int{1}
+	if got := intValues(it); !reflect.DeepEqual(got, want) {
+		t.Errorf("Iterator returned %v, want %v", got, want)
+	}
+}
+
+func TestIterator_error(t *testing.T) {
+	e := errors.New("e")
+	it := newIterator("", func(offset string) ([]*int, string, error) {
+		return nil, "", e
+	})
+
+	if it.Next() {
+		t.Error("Iterator.Next returned true on error")
+	}
+	if it.Err() != e {
+		t.Errorf("Iterator.Err returned %v, want %v", it.Err(), e)
+	}
+}
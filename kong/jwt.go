@@ -0,0 +1,162 @@
+package kong
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+)
+
+// JWTKey is a jwt credential registered with Kong together with the
+// private material needed to sign tokens Kong accepts for it.
+type JWTKey struct {
+	*ConsumerJWTConfig // The credential as stored by Kong.
+
+	// PrivateKey is the private half of the key pair of an RS256 or ES256
+	// credential, an *rsa.PrivateKey or *ecdsa.PrivateKey. It is nil for
+	// HS256 credentials, which are signed with Secret.
+	PrivateKey crypto.Signer
+}
+
+// Generate creates the signing material for a jwt credential locally,
+// registers the credential with Kong and returns it along with the
+// private material.
+//
+// config.Algorithm selects the kind of credential, HS256 when empty:
+//
+//	HS256  a random Secret is generated
+//	RS256  a 2048 bit RSA key pair is generated and RSAPublicKey set
+//	ES256  a P-256 ECDSA key pair is generated and RSAPublicKey set
+//
+// The remaining fields of config, such as Key, are sent as given. config
+// may be nil, in which case Kong also generates the key.
+//
+// Equivalent to POST /consumers/{username or id}/jwt
+func (s *ConsumersJWTService) Generate(consumer string, config *ConsumerJWTConfig) (*JWTKey, *http.Response, error) {
+	return s.GenerateWithContext(context.Background(), consumer, config)
+}
+
+// GenerateWithContext is like Generate but uses ctx for the request.
+func (s *ConsumersJWTService) GenerateWithContext(ctx context.Context, consumer string, config *ConsumerJWTConfig) (*JWTKey, *http.Response, error) {
+	c := new(ConsumerJWTConfig)
+	if config != nil {
+		*c = *config
+	}
+	if c.Algorithm == "" {
+		c.Algorithm = "HS256"
+	}
+
+	var private crypto.Signer
+	var err error
+	switch c.Algorithm {
+	case "HS256":
+		secret := make([]byte, 32)
+		_, err = rand.Read(secret)
+		c.Secret = hex.EncodeToString(secret)
+	case "RS256":
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	case "ES256":
+		private, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, nil, fmt.Errorf("unsupported algorithm %q, want HS256, RS256 or ES256", c.Algorithm)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if private != nil {
+		der, err := x509.MarshalPKIXPublicKey(private.Public())
+		if err != nil {
+			return nil, nil, err
+		}
+		c.RSAPublicKey = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	}
+
+	credential, resp, err := s.PostWithContext(ctx, consumer, c)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &JWTKey{ConsumerJWTConfig: credential, PrivateKey: private}, resp, err
+}
+
+// PrivateKeyPEM returns PrivateKey PEM encoded in PKCS #8 form, or an
+// empty string for HS256 credentials.
+func (k *JWTKey) PrivateKeyPEM() (string, error) {
+	if k.PrivateKey == nil {
+		return "", nil
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(k.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// Sign mints a token for the credential carrying claims. The iss claim,
+// which Kong uses to find the credential by default, is set to Key unless
+// claims already holds one.
+//
+// i.e. k.Sign(map[string]interface{}{"exp": time.Now().Add(time.Hour).Unix()})
+func (k *JWTKey) Sign(claims map[string]interface{}) (string, error) {
+	c := map[string]interface{}{"iss": k.Key}
+	for name, v := range claims {
+		c[name] = v
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": k.Algorithm, "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString(header) + "." + enc.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(unsigned))
+
+	var sig []byte
+	switch key := k.PrivateKey.(type) {
+	case nil:
+		if k.Algorithm != "HS256" {
+			return "", fmt.Errorf("no private key to sign %v tokens with", k.Algorithm)
+		}
+		mac := hmac.New(sha256.New, []byte(k.Secret))
+		mac.Write([]byte(unsigned))
+		sig = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	case *ecdsa.PrivateKey:
+		// JWS wants the fixed size r || s form rather than ASN.1
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		if err != nil {
+			return "", err
+		}
+		size := (key.Curve.Params().BitSize + 7) / 8
+		sig = make([]byte, 2*size)
+		r.FillBytes(sig[:size])
+		s.FillBytes(sig[size:])
+	default:
+		return "", fmt.Errorf("unsupported private key type %T", k.PrivateKey)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return unsigned + "." + enc.EncodeToString(sig), nil
+}
@@ -0,0 +1,156 @@
+package kong
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// stubJWTPost registers a handler storing the jwt credential posted for
+// consumer bob the way Kong does, generating its key.
+func stubJWTPost(t *testing.T) {
+	mux.HandleFunc("/consumers/bob/jwt", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		v := new(ConsumerJWTConfig)
+		json.NewDecoder(r.Body).Decode(v)
+		v.ID, v.ConsumerID, v.Key = "j1", "c1", "iss"
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(v)
+	})
+}
+
+// splitToken returns the claims of a signed token, along with the signed
+// part and the signature.
+func splitToken(t *testing.T, token string) (map[string]interface{}, []byte, []byte) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("token %q does not have three parts", token)
+	}
+
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	claims := make(map[string]interface{})
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatalf("decoding claims: %v", err)
+	}
+
+	sig, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	return claims, []byte(parts[0] + "." + parts[1]), sig
+}
+
+func TestConsumersJWTService_Generate_HS256(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	stubJWTPost(t)
+
+	key, _, err := client.Consumers.Plugins.JWT.Generate("bob", nil)
+	if err != nil {
+		t.Fatalf("JWT.Generate returned error: %v", err)
+	}
+	if key.ID != "j1" || key.Algorithm != "HS256" || len(key.Secret) != 64 || key.PrivateKey != nil {
+		t.Errorf("JWT.Generate returned %+v, want a stored HS256 credential", key.ConsumerJWTConfig)
+	}
+
+	token, err := key.Sign(map[string]interface{}{"sub": "bob"})
+	if err != nil {
+		t.Fatalf("JWTKey.Sign returned error: %v", err)
+	}
+
+	claims, signed, sig := splitToken(t, token)
+	if claims["iss"] != "iss" || claims["sub"] != "bob" {
+		t.Errorf("JWTKey.Sign claims = %v, want iss and sub", claims)
+	}
+
+	mac := hmac.New(sha256.New, []byte(key.Secret))
+	mac.Write(signed)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		t.Error("JWTKey.Sign returned a token with an invalid HS256 signature")
+	}
+}
+
+func TestConsumersJWTService_Generate_RS256(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	stubJWTPost(t)
+
+	key, _, err := client.Consumers.Plugins.JWT.Generate("bob", &ConsumerJWTConfig{Algorithm: "RS256"})
+	if err != nil {
+		t.Fatalf("JWT.Generate returned error: %v", err)
+	}
+
+	block, _ := pem.Decode([]byte(key.RSAPublicKey))
+	if block == nil {
+		t.Fatalf("JWT.Generate registered rsa_public_key %q, want a PEM block", key.RSAPublicKey)
+	}
+	public, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("parsing rsa_public_key: %v", err)
+	}
+
+	token, err := key.Sign(map[string]interface{}{"iss": "other"})
+	if err != nil {
+		t.Fatalf("JWTKey.Sign returned error: %v", err)
+	}
+
+	claims, signed, sig := splitToken(t, token)
+	if claims["iss"] != "other" {
+		t.Errorf("JWTKey.Sign iss = %v, want the given one", claims["iss"])
+	}
+
+	digest := sha256.Sum256(signed)
+	if err := rsa.VerifyPKCS1v15(public.(*rsa.PublicKey), crypto.SHA256, digest[:], sig); err != nil {
+		t.Errorf("JWTKey.Sign returned a token with an invalid RS256 signature: %v", err)
+	}
+
+	if private, err := key.PrivateKeyPEM(); err != nil || !strings.Contains(private, "PRIVATE KEY") {
+		t.Errorf("JWTKey.PrivateKeyPEM returned %q, %v", private, err)
+	}
+}
+
+func TestConsumersJWTService_Generate_ES256(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	stubJWTPost(t)
+
+	key, _, err := client.Consumers.Plugins.JWT.Generate("bob", &ConsumerJWTConfig{Algorithm: "ES256"})
+	if err != nil {
+		t.Fatalf("JWT.Generate returned error: %v", err)
+	}
+
+	token, err := key.Sign(nil)
+	if err != nil {
+		t.Fatalf("JWTKey.Sign returned error: %v", err)
+	}
+
+	_, signed, sig := splitToken(t, token)
+	if len(sig) != 64 {
+		t.Fatalf("JWTKey.Sign returned a %d byte signature, want 64", len(sig))
+	}
+
+	digest := sha256.Sum256(signed)
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+	if !ecdsa.Verify(key.PrivateKey.Public().(*ecdsa.PublicKey), digest[:], r, s) {
+		t.Error("JWTKey.Sign returned a token with an invalid ES256 signature")
+	}
+}
+
+func TestConsumersJWTService_Generate_unsupported(t *testing.T) {
+	_, _, err := client.Consumers.Plugins.JWT.Generate("bob", &ConsumerJWTConfig{Algorithm: "HS512"})
+	if err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
@@ -2,13 +2,18 @@ package kong
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"reflect"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -19,7 +24,8 @@ const (
 )
 
 // Client manages communication with the Kong API.
-// New client objects should be created using the NewClient function.
+// New client objects should be created using the NewClientWithOptions
+// or NewClient functions.
 // The BaseURL field must be defined and pointed at an instance of the
 // Kong Admin API.
 //
@@ -33,17 +39,52 @@ type Client struct {
 	// BaseURL should always be specified with a trailing slash
 	BaseURL *url.URL
 
+	// RetryPolicy controls whether and how Do retries requests that
+	// fail with a transient error. Requests are attempted only once
+	// when RetryPolicy is nil.
+	RetryPolicy *RetryPolicy
+
+	// Header holds headers sent with every request, such as the apikey
+	// or Kong-Admin-Token of an Admin API protected by key-auth or RBAC.
+	Header http.Header
+
+	// Username and Password are sent as basic auth credentials with
+	// every request when Username is set.
+	Username string
+	Password string
+
+	// Signer, when set, is called on every request built by NewRequest
+	// after the credentials above have been added.
+	Signer RequestSigner
+
+	// UserAgent, when set, is sent as the User-Agent header of every
+	// request.
+	UserAgent string
+
+	// Logger, when set, is used to log every request sent and every
+	// retry.
+	Logger Logger
+
 	// Reuse a single struct instead of allocating one for each service on the heap
 	common service
 
 	// Services used for talking to different parts of the Kong API
-	Node      *NodeService
-	Cluster   *ClusterService
-	Apis      *ApisService
-	Upstreams *UpstreamsService
-	Targets   *TargetsService
-	Consumers *ConsumersService
-	Plugins   *PluginsService
+	Node         *NodeService
+	Cluster      *ClusterService
+	Apis         *ApisService
+	Services     *ServicesService
+	Routes       *RoutesService
+	Certificates *CertificatesService
+	SNIs         *SNIsService
+	Upstreams    *UpstreamsService
+	Targets      *TargetsService
+	Consumers    *ConsumersService
+	Plugins      *PluginsService
+	OAuth2Tokens *OAuth2TokensService
+	KeyAuths     *KeyAuthsService
+	JWTs         *JWTsService
+	ACLs         *ACLsService
+	BasicAuths   *BasicAuthsService
 }
 
 // Each service representing a Kong resource type will be of this type
@@ -77,27 +118,76 @@ func addOptions(s string, opt interface{}) (string, error) {
 	return u.String(), nil
 }
 
+// Bool returns a pointer to v. It is a convenience for setting optional
+// fields such as Route.StripPath, where false has to be distinguished
+// from unset.
+func Bool(v bool) *bool {
+	return &v
+}
+
+// Int returns a pointer to v. It is a convenience for setting optional
+// fields such as Service.Retries, where 0 has to be distinguished from
+// unset.
+func Int(v int) *int {
+	return &v
+}
+
+// Float64 returns a pointer to v, for the optional numbers of the config
+// structs generated by kong-plugin-gen.
+func Float64(v float64) *float64 {
+	return &v
+}
+
+// String returns a pointer to v, for the optional strings of the config
+// structs generated by kong-plugin-gen.
+func String(v string) *string {
+	return &v
+}
+
 // NewClient creates a new kong.Client object.
-// This should be the primary way a kong.Client object is constructed.
 //
 // If an httpClient object is specified it will be used instead of the
 // default http.DefaultClient.
 //
-// baseURLStr should point to an instance a Kong Admin API and must
-// contain the trailing slash. i.e. http://kong:8001/
+// baseURLStr should point to an instance a Kong Admin API, i.e.
+// http://kong:8001/. It is a shorthand for
+//
+//	NewClientWithOptions(baseURLStr, WithHTTPClient(httpClient))
 func NewClient(httpClient *http.Client, baseURLStr string) (*Client, error) {
-	if httpClient == nil {
-		httpClient = http.DefaultClient
-	}
+	return NewClientWithOptions(baseURLStr, WithHTTPClient(httpClient))
+}
 
+// NewClientWithOptions creates a new kong.Client object configured by opts.
+// This should be the primary way a kong.Client object is constructed.
+//
+// baseURLStr should point to an instance a Kong Admin API, i.e.
+// http://kong:8001/ or https://example.com/kong-admin. A trailing slash
+// is added to its path if missing, so paths resolve beneath it.
+func NewClientWithOptions(baseURLStr string, opts ...Option) (*Client, error) {
 	baseURL, err := url.Parse(baseURLStr)
 	if err != nil {
 		return nil, err
 	}
+	if !strings.HasSuffix(baseURL.Path, "/") {
+		baseURL.Path += "/"
+		if baseURL.RawPath != "" {
+			baseURL.RawPath += "/"
+		}
+	}
 
-	c := &Client{client: httpClient, BaseURL: baseURL}
+	c := &Client{BaseURL: baseURL}
 	c.common.client = c
 
+	o := &clientOptions{client: c}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	if c.client, err = o.httpClient(); err != nil {
+		return nil, err
+	}
+
 	// Share a single client among all of the services
 	c.Node = (*NodeService)(&c.common)
 	c.Cluster = (*ClusterService)(&c.common)
@@ -105,6 +195,21 @@ func NewClient(httpClient *http.Client, baseURLStr string) (*Client, error) {
 		service: &c.common,
 		Plugins: (*ApisPluginsService)(&c.common),
 	}
+	c.Services = &ServicesService{
+		service: &c.common,
+		Routes:  (*ServicesRoutesService)(&c.common),
+		Plugins: (*ServicesPluginsService)(&c.common),
+	}
+	c.Routes = &RoutesService{
+		service: &c.common,
+	}
+	c.Certificates = &CertificatesService{
+		service: &c.common,
+		SNIs:    (*CertificatesSNIsService)(&c.common),
+	}
+	c.SNIs = &SNIsService{
+		service: &c.common,
+	}
 	c.Upstreams = &UpstreamsService{
 		service: &c.common,
 	}
@@ -114,12 +219,30 @@ func NewClient(httpClient *http.Client, baseURLStr string) (*Client, error) {
 	c.Consumers = &ConsumersService{
 		service: &c.common,
 		Plugins: &ConsumersPlugins{
-			ACL:     (*ConsumersACLService)(&c.common),
-			JWT:     (*ConsumersJWTService)(&c.common),
-			KeyAuth: (*ConsumersKeyAuthService)(&c.common),
+			ACL:       (*ConsumersACLService)(&c.common),
+			JWT:       (*ConsumersJWTService)(&c.common),
+			KeyAuth:   (*ConsumersKeyAuthService)(&c.common),
+			BasicAuth: (*ConsumersBasicAuthService)(&c.common),
+			HMACAuth:  (*ConsumersHMACAuthService)(&c.common),
+			OAuth2:    (*ConsumersOAuth2Service)(&c.common),
 		},
 	}
 	c.Plugins = (*PluginsService)(&c.common)
+	c.OAuth2Tokens = &OAuth2TokensService{
+		service: &c.common,
+	}
+	c.KeyAuths = &KeyAuthsService{
+		service: &c.common,
+	}
+	c.JWTs = &JWTsService{
+		service: &c.common,
+	}
+	c.ACLs = &ACLsService{
+		service: &c.common,
+	}
+	c.BasicAuths = &BasicAuthsService{
+		service: &c.common,
+	}
 
 	return c, nil
 }
@@ -132,6 +255,13 @@ func NewClient(httpClient *http.Client, baseURLStr string) (*Client, error) {
 // If body is provided, it will be JSON encoded and used as the request
 // body.
 func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, urlStr, body)
+}
+
+// NewRequestWithContext is like NewRequest but attaches ctx to the returned
+// *http.Request. Canceling ctx, or letting its deadline pass, aborts the
+// request when it is later executed by Client.Do.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
 	rel, err := url.Parse(urlStr)
 	if err != nil {
 		return nil, err
@@ -141,7 +271,7 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 
 	// Kong does not like empty bodies
 	if method == "POST" || method == "PATCH" {
-		if reflect.ValueOf(body).IsNil() {
+		if body == nil || reflect.ValueOf(body).IsNil() {
 			body = struct{}{}
 		}
 	}
@@ -155,7 +285,7 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 		}
 	}
 
-	req, err := http.NewRequest(method, u.String(), buf)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
 	if err != nil {
 		return nil, err
 	}
@@ -166,6 +296,14 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 
 	req.Header.Set("Accept", "application/json")
 
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	if err := c.authenticate(req); err != nil {
+		return nil, err
+	}
+
 	return req, nil
 }
 
@@ -179,12 +317,27 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 // passed back to the caller. If Kong returns a status code outside
 // of the 200 range, the caller can inspect the *http.Response to
 // get more information. Additionally the err returned in this case
-// will be of type ErrorResponse.
+// will be an *ErrorResponse, or one of the types wrapping it for the
+// status codes CheckResponse distinguishes.
+//
+// The request is bound to the context it was created with. If that
+// context is canceled or times out, Do returns the context's error.
+//
+// If c.RetryPolicy is set, transient failures are retried before Do
+// gives up and returns the last response or error.
 func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
-	resp, err := c.client.Do(req)
+	start := time.Now()
+	resp, err := c.do(req)
 	if err != nil {
+		// The context error is more useful to callers than the
+		// *url.Error the transport wraps it in
+		if ctxErr := req.Context().Err(); ctxErr != nil {
+			err = ctxErr
+		}
+		c.logf("%v %v: %v", req.Method, req.URL, err)
 		return nil, err
 	}
+	c.logf("%v %v: %d in %v", req.Method, req.URL, resp.StatusCode, time.Since(start))
 
 	err = CheckResponse(req, resp)
 	if err != nil {
@@ -217,9 +370,81 @@ type ErrorResponse struct {
 }
 
 func (r *ErrorResponse) Error() string {
+	req := r.Request
+	if req == nil && r.Response != nil {
+		req = r.Response.Request
+	}
+
+	status := 0
+	if r.Response != nil {
+		status = r.Response.StatusCode
+	}
+
+	if req == nil {
+		return fmt.Sprintf("%d %v %v", status, r.KongMessage, r.KongError)
+	}
 	return fmt.Sprintf("%v %v: %d %v %v",
-		r.Response.Request.Method, r.Response.Request.URL,
-		r.Response.StatusCode, r.KongMessage, r.KongError)
+		req.Method, req.URL, status, r.KongMessage, r.KongError)
+}
+
+// statusCode returns the status code of the response, or 0 if there is none.
+func (r *ErrorResponse) statusCode() int {
+	if r.Response == nil {
+		return 0
+	}
+	return r.Response.StatusCode
+}
+
+// SchemaViolationError occurs when Kong rejects an entity as invalid.
+// CheckResponse will return this type of error when Kong returns a 400 status code.
+type SchemaViolationError struct {
+	*ErrorResponse
+	Fields []*FieldError // The fields Kong rejected along with why, sorted by field
+}
+
+func (r *SchemaViolationError) Error() string {
+	// Older versions of Kong only list the fields, without a message
+	if r.KongMessage != "" || r.KongError != "" || len(r.Fields) == 0 {
+		return r.ErrorResponse.Error()
+	}
+
+	msgs := make([]string, len(r.Fields))
+	for i, f := range r.Fields {
+		msgs[i] = f.Error()
+	}
+	e := *r.ErrorResponse
+	e.KongMessage = strings.Join(msgs, "; ")
+	return e.Error()
+}
+
+func (r *SchemaViolationError) Unwrap() error {
+	return r.ErrorResponse
+}
+
+// UnauthorizedError occurs when the Admin API requires credentials which
+// were missing or wrong.
+// CheckResponse will return this type of error when Kong returns a 401 status code.
+type UnauthorizedError ErrorResponse
+
+func (r *UnauthorizedError) Error() string {
+	return (*ErrorResponse)(r).Error()
+}
+
+func (r *UnauthorizedError) Unwrap() error {
+	return (*ErrorResponse)(r)
+}
+
+// ForbiddenError occurs when the credentials used are not allowed to
+// perform the request.
+// CheckResponse will return this type of error when Kong returns a 403 status code.
+type ForbiddenError ErrorResponse
+
+func (r *ForbiddenError) Error() string {
+	return (*ErrorResponse)(r).Error()
+}
+
+func (r *ForbiddenError) Unwrap() error {
+	return (*ErrorResponse)(r)
 }
 
 // ConflictError occurs when trying to create a resource that already exists.
@@ -230,6 +455,10 @@ func (r *ConflictError) Error() string {
 	return (*ErrorResponse)(r).Error()
 }
 
+func (r *ConflictError) Unwrap() error {
+	return (*ErrorResponse)(r)
+}
+
 // NotFoundError occurs when trying to access a resource that does not exist.
 // CheckResponse will return this type of error when Kong returns a 404 status code.
 type NotFoundError ErrorResponse
@@ -238,6 +467,62 @@ func (r *NotFoundError) Error() string {
 	return (*ErrorResponse)(r).Error()
 }
 
+func (r *NotFoundError) Unwrap() error {
+	return (*ErrorResponse)(r)
+}
+
+// TooManyRequestsError occurs when a rate limit in front of the Admin API
+// was exceeded.
+// CheckResponse will return this type of error when Kong returns a 429 status code.
+type TooManyRequestsError ErrorResponse
+
+func (r *TooManyRequestsError) Error() string {
+	return (*ErrorResponse)(r).Error()
+}
+
+func (r *TooManyRequestsError) Unwrap() error {
+	return (*ErrorResponse)(r)
+}
+
+// RetryAfter returns how long the Retry-After header of the response asks
+// to wait before trying again, or 0 if it has none.
+func (r *TooManyRequestsError) RetryAfter() time.Duration {
+	d, _ := retryAfter(r.Response)
+	return d
+}
+
+// ServerError occurs when Kong, or a proxy in front of it, fails to handle
+// the request.
+// CheckResponse will return this type of error when Kong returns a 5xx status code.
+type ServerError ErrorResponse
+
+func (r *ServerError) Error() string {
+	return (*ErrorResponse)(r).Error()
+}
+
+func (r *ServerError) Unwrap() error {
+	return (*ErrorResponse)(r)
+}
+
+// IsNotFound reports whether err, or any error it wraps, is a *NotFoundError.
+func IsNotFound(err error) bool {
+	var e *NotFoundError
+	return errors.As(err, &e)
+}
+
+// IsConflict reports whether err, or any error it wraps, is a *ConflictError.
+func IsConflict(err error) bool {
+	var e *ConflictError
+	return errors.As(err, &e)
+}
+
+// IsRetryable reports whether err, or any error it wraps, is transient
+// under DefaultRetryPolicy. It does not know about the RetryPolicy a
+// Client is configured with, use RetryPolicy.IsRetryable for that.
+func IsRetryable(err error) bool {
+	return DefaultRetryPolicy().IsRetryable(err)
+}
+
 // CheckResponse looks at the response from a Kong API call
 // and determines what type of error needs to be returned, if any.
 func CheckResponse(req *http.Request, r *http.Response) error {
@@ -257,12 +542,82 @@ func CheckResponse(req *http.Request, r *http.Response) error {
 	// Restore r.Body to its original state after reading
 	r.Body = ioutil.NopCloser(bytes.NewBuffer(data))
 
-	switch r.StatusCode {
-	case 404:
+	switch c := r.StatusCode; {
+	case c == 400:
+		return &SchemaViolationError{
+			ErrorResponse: errorResponse,
+			Fields:        schemaViolations(data),
+		}
+	case c == 401:
+		return (*UnauthorizedError)(errorResponse)
+	case c == 403:
+		return (*ForbiddenError)(errorResponse)
+	case c == 404:
 		return (*NotFoundError)(errorResponse)
-	case 409:
+	case c == 409:
 		return (*ConflictError)(errorResponse)
+	case c == 429:
+		return (*TooManyRequestsError)(errorResponse)
+	case c >= 500:
+		return (*ServerError)(errorResponse)
 	default:
 		return errorResponse
 	}
 }
+
+// schemaViolations returns the per-field messages in the body of a 400
+// response, keyed by dotted path for nested fields. Kong lists them under
+// "fields", while versions before 1.0 send nothing but the fields. Other
+// bodies, such as {"error": "..."}, hold no field violations.
+func schemaViolations(data []byte) []*FieldError {
+	var body map[string]interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil
+	}
+
+	fields, ok := body["fields"].(map[string]interface{})
+	if !ok {
+		if _, ok := body["message"]; ok {
+			return nil
+		}
+
+		// Leave out the keys of Kong's error bodies. A name without a
+		// code is the name field of the entity.
+		fields = make(map[string]interface{})
+		_, hasCode := body["code"]
+		for k, v := range body {
+			if k == "error" || k == "code" || k == "name" && hasCode {
+				continue
+			}
+			fields[k] = v
+		}
+	}
+
+	var errs []*FieldError
+	collectViolations(&errs, "", fields)
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+	return errs
+}
+
+func collectViolations(errs *[]*FieldError, prefix string, fields map[string]interface{}) {
+	for name, v := range fields {
+		field := prefix + name
+		switch v := v.(type) {
+		case string:
+			*errs = append(*errs, &FieldError{Field: field, Message: v})
+		case []interface{}:
+			// Errors of array elements, null for the valid ones
+			var msgs []string
+			for _, e := range v {
+				if msg, ok := e.(string); ok {
+					msgs = append(msgs, msg)
+				}
+			}
+			if len(msgs) > 0 {
+				*errs = append(*errs, &FieldError{Field: field, Message: strings.Join(msgs, ", ")})
+			}
+		case map[string]interface{}:
+			collectViolations(errs, field+".", v)
+		}
+	}
+}
@@ -2,14 +2,20 @@ package kong
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"reflect"
+	"syscall"
 	"testing"
+	"time"
 )
 
 var (
@@ -151,7 +157,7 @@ func TestAddOptions_badUrl(t *testing.T) {
 func TestNewClient(t *testing.T) {
 	c, _ := NewClient(nil, "http://test:8001")
 
-	if got, want := c.BaseURL.String(), "http://test:8001"; got != want {
+	if got, want := c.BaseURL.String(), "http://test:8001/"; got != want {
 		t.Errorf("NewClient BaseURL is %v, want %v", got, want)
 	}
 
@@ -224,6 +230,37 @@ func TestNewRequest_emptyBody(t *testing.T) {
 	}
 }
 
+func TestNewRequestWithContext(t *testing.T) {
+	c, _ := NewClient(nil, defaultBaseURL)
+
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "v")
+
+	req, err := c.NewRequestWithContext(ctx, "GET", "foo", nil)
+	if err != nil {
+		t.Fatalf("NewRequestWithContext returned unexpected error: %v", err)
+	}
+
+	if got := req.Context().Value(key{}); got != "v" {
+		t.Errorf("NewRequestWithContext context value is %v, want %v", got, "v")
+	}
+}
+
+// Kong rejects POST and PATCH requests without a body, so a nil body
+// must be sent as an empty JSON object.
+func TestNewRequest_postNilBody(t *testing.T) {
+	c, _ := NewClient(nil, defaultBaseURL)
+	req, err := c.NewRequest("POST", "/", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned unexpected error: %v", err)
+	}
+
+	body, _ := ioutil.ReadAll(req.Body)
+	if got, want := string(body), "{}\n"; got != want {
+		t.Errorf("NewRequest Body is %q, want %q", got, want)
+	}
+}
+
 func TestDo(t *testing.T) {
 	stubSetup()
 	defer stubTeardown()
@@ -265,6 +302,48 @@ func TestDo_httpError(t *testing.T) {
 	}
 }
 
+func TestDo_canceledContext(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Request should not have reached the server")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, _ := client.NewRequestWithContext(ctx, "GET", "/", nil)
+	_, err := client.Do(req, nil)
+	if err != context.Canceled {
+		t.Errorf("Do returned error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestDo_deadlineExceeded(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	done := make(chan struct{})
+	defer close(done)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, _ := client.NewRequestWithContext(ctx, "GET", "/", nil)
+	_, err := client.Do(req, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Do returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
 func TestDo_noContent(t *testing.T) {
 	stubSetup()
 	defer stubTeardown()
@@ -357,10 +436,183 @@ func TestCheckResponse_badStatusCode(t *testing.T) {
 	}
 	req, _ := http.NewRequest("GET", "/url", nil)
 	err := CheckResponse(req, r)
-	_, ok := err.(*ErrorResponse)
+	_, ok := err.(*SchemaViolationError)
+	if !ok {
+		t.Fatal("CheckResponse returned the incorrect error type")
+	}
+}
+
+func TestCheckResponse_statusCodes(t *testing.T) {
+	tests := map[int]string{
+		401: "*kong.UnauthorizedError",
+		403: "*kong.ForbiddenError",
+		405: "*kong.ErrorResponse",
+		429: "*kong.TooManyRequestsError",
+		500: "*kong.ServerError",
+		503: "*kong.ServerError",
+	}
+
+	for code, want := range tests {
+		r := &http.Response{
+			StatusCode: code,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"message": "m"}`)),
+		}
+		req, _ := http.NewRequest("GET", "/url", nil)
+		err := CheckResponse(req, r)
+		if got := fmt.Sprintf("%T", err); got != want {
+			t.Errorf("CheckResponse of a %d returned %v, want %v", code, got, want)
+		}
+
+		var errResp *ErrorResponse
+		if !errors.As(err, &errResp) || errResp.KongMessage != "m" {
+			t.Errorf("CheckResponse of a %d returned %v, want it to unwrap to an *ErrorResponse", code, err)
+		}
+	}
+}
+
+func TestCheckResponse_schemaViolation(t *testing.T) {
+	r := &http.Response{
+		StatusCode: 400,
+		Body: ioutil.NopCloser(bytes.NewBufferString(`{
+			"code": 2,
+			"name": "schema violation",
+			"message": "2 schema violations",
+			"fields": {
+				"name": "required field missing",
+				"config": {"max_age": "expected a number"},
+				"methods": [null, "invalid value: GOT"]
+			}
+		}`)),
+	}
+	req, _ := http.NewRequest("POST", "/plugins", nil)
+	err, ok := CheckResponse(req, r).(*SchemaViolationError)
 	if !ok {
 		t.Fatal("CheckResponse returned the incorrect error type")
 	}
+
+	want := []*FieldError{
+		{Field: "config.max_age", Message: "expected a number"},
+		{Field: "methods", Message: "invalid value: GOT"},
+		{Field: "name", Message: "required field missing"},
+	}
+	if !reflect.DeepEqual(err.Fields, want) {
+		t.Errorf("SchemaViolationError.Fields = %v, want %v", err.Fields, want)
+	}
+	if err.KongMessage != "2 schema violations" {
+		t.Errorf("SchemaViolationError.KongMessage = %q, want %q", err.KongMessage, "2 schema violations")
+	}
+}
+
+func TestCheckResponse_schemaViolationLegacy(t *testing.T) {
+	r := &http.Response{
+		StatusCode: 400,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"upstream_url":"upstream_url is required","name":"name is required"}`)),
+	}
+	req, _ := http.NewRequest("POST", "http://test/apis", nil)
+	err := CheckResponse(req, r)
+
+	want := "POST http://test/apis: 400 name: name is required; upstream_url: upstream_url is required "
+	if err.Error() != want {
+		t.Errorf("SchemaViolationError.Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestCheckResponse_schemaViolationNoFields(t *testing.T) {
+	for _, body := range []string{`{"error":"bad request"}`, `{"name":"bad request","code":2}`} {
+		r := &http.Response{
+			StatusCode: 400,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+		req, _ := http.NewRequest("POST", "http://test/apis", nil)
+		err, ok := CheckResponse(req, r).(*SchemaViolationError)
+		if !ok {
+			t.Fatal("CheckResponse returned the incorrect error type")
+		}
+		if len(err.Fields) != 0 {
+			t.Errorf("%v: SchemaViolationError.Fields = %v, want none", body, err.Fields)
+		}
+	}
+}
+
+func TestErrorResponse_Error_noResponseRequest(t *testing.T) {
+	url, _ := url.Parse("http://test")
+	e := &ErrorResponse{
+		Request:     &http.Request{Method: "m", URL: url},
+		Response:    &http.Response{StatusCode: 500},
+		KongMessage: "m",
+	}
+	want := "m http://test: 500 m "
+	if e.Error() != want {
+		t.Errorf("ErrorResponse.Error() = %q, want %q", e.Error(), want)
+	}
+
+	e = &ErrorResponse{KongMessage: "m"}
+	want = "0 m "
+	if e.Error() != want {
+		t.Errorf("ErrorResponse.Error() = %q, want %q", e.Error(), want)
+	}
+}
+
+func TestTooManyRequestsError_RetryAfter(t *testing.T) {
+	e := &TooManyRequestsError{Response: &http.Response{Header: http.Header{"Retry-After": {"3"}}}}
+	if got := e.RetryAfter(); got != 3*time.Second {
+		t.Errorf("RetryAfter() = %v, want 3s", got)
+	}
+
+	e = &TooManyRequestsError{Response: &http.Response{}}
+	if got := e.RetryAfter(); got != 0 {
+		t.Errorf("RetryAfter() = %v, want 0", got)
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	err := fmt.Errorf("getting api: %w", &NotFoundError{})
+	if !IsNotFound(err) {
+		t.Error("IsNotFound of a wrapped *NotFoundError = false, want true")
+	}
+	if IsNotFound(&ConflictError{}) || IsNotFound(nil) {
+		t.Error("IsNotFound of another error = true, want false")
+	}
+}
+
+func TestIsConflict(t *testing.T) {
+	err := fmt.Errorf("creating api: %w", &ConflictError{})
+	if !IsConflict(err) {
+		t.Error("IsConflict of a wrapped *ConflictError = false, want true")
+	}
+	if IsConflict(&NotFoundError{}) || IsConflict(nil) {
+		t.Error("IsConflict of another error = true, want false")
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	status := func(code int) *http.Response { return &http.Response{StatusCode: code} }
+
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&TooManyRequestsError{Response: status(429)}, true},
+		{fmt.Errorf("wrapped: %w", &ServerError{Response: status(503)}), true},
+		{&ServerError{Response: status(501)}, false},
+		{&NotFoundError{Response: status(404)}, false},
+		{&SchemaViolationError{ErrorResponse: &ErrorResponse{Response: status(400)}}, false},
+		{&url.Error{Op: "Get", URL: "http://test", Err: &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}}, true},
+		{&url.Error{Op: "Get", URL: "http://test", Err: &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}}, true},
+		{&url.Error{Op: "Get", URL: "http://test", Err: &net.OpError{Op: "dial", Err: os.ErrDeadlineExceeded}}, true},
+		{&url.Error{Op: "Get", URL: "http://test", Err: &net.DNSError{Err: "no such host", Name: "test", IsNotFound: true}}, false},
+		{&url.Error{Op: "Get", URL: "https://test", Err: errors.New("tls: failed to verify certificate")}, false},
+		{context.Canceled, false},
+		{context.DeadlineExceeded, false},
+		{&url.Error{Op: "Get", URL: "http://test", Err: context.DeadlineExceeded}, false},
+		{nil, false},
+	}
+
+	for _, test := range tests {
+		if got := IsRetryable(test.err); got != test.want {
+			t.Errorf("IsRetryable(%v) = %v, want %v", test.err, got, test.want)
+		}
+	}
 }
 
 func TestCheckResponse_notFoundStatusCode(t *testing.T) {
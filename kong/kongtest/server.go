@@ -0,0 +1,637 @@
+// Package kongtest provides an in-memory fake of the Kong Admin API, so
+// code built on kong.Client can be tested without running Kong.
+//
+//	srv := kongtest.NewServer()
+//	defer srv.Close()
+//
+//	client := srv.Client()
+//	client.Apis.Post(&kong.ApiRequest{Name: "mockbin", UpstreamURL: "http://mockbin.org"})
+//
+// The server keeps apis, consumers and their acl, jwt, key-auth,
+// basic-auth and hmac-auth credentials, plugins, upstreams and targets.
+// Like Kong it assigns ids and creation times, answers 404 Not Found for
+// unknown entities and 409 Conflict when a unique field is reused,
+// deletes dependent entities along with the ones they belong to and
+// paginates listings through the size and offset query parameters.
+package kongtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nccurry/go-kong/kong"
+)
+
+// Server is a fake Kong Admin API listening on a local address. It is
+// safe for concurrent use.
+type Server struct {
+	URL string // Base URL of the fake Admin API, i.e. http://127.0.0.1:1234
+
+	server *httptest.Server
+
+	mu         sync.Mutex
+	apis       *table
+	consumers  *table
+	plugins    *table
+	acls       *table
+	jwts       *table
+	keyAuths   *table
+	basicAuths *table
+	hmacAuths  *table
+	upstreams  *table
+	targets    *table
+}
+
+// NewServer starts and returns a new Server holding no entities. The
+// caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := new(Server)
+
+	s.plugins = &table{
+		unique:   [][]string{{"name", "api_id", "consumer_id"}},
+		required: []string{"name"},
+		defaults: func() row { return row{"enabled": true, "config": map[string]interface{}{}} },
+		check:    s.checkPlugin,
+	}
+	s.apis = &table{
+		keys:     []string{"name"},
+		unique:   [][]string{{"name"}},
+		required: []string{"name", "upstream_url"},
+		defaults: func() row {
+			return row{
+				"retries":                  float64(5),
+				"upstream_connect_timeout": float64(60000),
+				"upstream_send_timeout":    float64(60000),
+				"upstream_read_timeout":    float64(60000),
+				"strip_uri":                true,
+			}
+		},
+		cascade: []ref{{s.plugins, "api_id"}},
+	}
+	s.acls = &table{
+		keys:     []string{"group"},
+		unique:   [][]string{{"group", "consumer_id"}},
+		required: []string{"group"},
+	}
+	s.jwts = &table{
+		keys:   []string{"key"},
+		unique: [][]string{{"key"}},
+		defaults: func() row {
+			return row{"key": newKey(), "secret": newKey(), "algorithm": "HS256"}
+		},
+	}
+	s.keyAuths = &table{
+		keys:     []string{"key"},
+		unique:   [][]string{{"key"}},
+		defaults: func() row { return row{"key": newKey()} },
+	}
+	s.basicAuths = &table{
+		keys:     []string{"username"},
+		unique:   [][]string{{"username"}},
+		required: []string{"username"},
+	}
+	s.hmacAuths = &table{
+		keys:     []string{"username"},
+		unique:   [][]string{{"username"}},
+		required: []string{"username"},
+		defaults: func() row { return row{"secret": newKey()} },
+	}
+	s.consumers = &table{
+		keys:   []string{"username"},
+		unique: [][]string{{"username"}, {"custom_id"}},
+		check: func(r row) fields {
+			if r.str("username") == "" && r.str("custom_id") == "" {
+				return fields{"@entity": "at least one of these fields must be non-empty: 'custom_id', 'username'"}
+			}
+			return nil
+		},
+		cascade: []ref{
+			{s.plugins, "consumer_id"},
+			{s.acls, "consumer_id"},
+			{s.jwts, "consumer_id"},
+			{s.keyAuths, "consumer_id"},
+			{s.basicAuths, "consumer_id"},
+			{s.hmacAuths, "consumer_id"},
+		},
+	}
+	s.targets = &table{
+		required: []string{"target"},
+		defaults: func() row { return row{"weight": float64(100)} },
+	}
+	s.upstreams = &table{
+		keys:     []string{"name"},
+		unique:   [][]string{{"name"}},
+		required: []string{"name"},
+		defaults: func() row { return row{"slots": float64(10000)} },
+		cascade:  []ref{{s.targets, "upstream_id"}},
+	}
+
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL
+
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a kong.Client which talks to the server.
+func (s *Server) Client() *kong.Client {
+	client, _ := kong.NewClient(s.server.Client(), s.URL+"/")
+	return client
+}
+
+// ServeHTTP answers a single Admin API request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch p[0] {
+	case "apis":
+		s.serveApis(w, r, p[1:])
+	case "consumers":
+		s.serveConsumers(w, r, p[1:])
+	case "plugins":
+		s.servePlugins(w, r, p[1:])
+	case "upstreams":
+		s.serveUpstreams(w, r, p[1:])
+	case "acls":
+		s.serveCredentials(w, r, s.acls, p[1:])
+	case "jwts":
+		s.serveCredentials(w, r, s.jwts, p[1:])
+	case "key-auths":
+		s.serveCredentials(w, r, s.keyAuths, p[1:])
+	case "basic-auths":
+		s.serveCredentials(w, r, s.basicAuths, p[1:])
+	default:
+		notFound(w)
+	}
+}
+
+// serveApis answers requests below /apis.
+func (s *Server) serveApis(w http.ResponseWriter, r *http.Request, p []string) {
+	switch len(p) {
+	case 0:
+		s.collection(w, r, s.apis, nil)
+		return
+	case 1:
+		s.entity(w, r, s.apis, p[0], nil)
+		return
+	}
+
+	api := s.apis.get(p[0], nil)
+	if api == nil || p[1] != "plugins" || len(p) > 3 {
+		notFound(w)
+		return
+	}
+
+	scope := row{"api_id": api["id"]}
+	if len(p) == 2 {
+		s.collection(w, r, s.plugins, scope)
+	} else {
+		s.entity(w, r, s.plugins, p[2], scope)
+	}
+}
+
+// serveConsumers answers requests below /consumers.
+func (s *Server) serveConsumers(w http.ResponseWriter, r *http.Request, p []string) {
+	switch len(p) {
+	case 0:
+		s.collection(w, r, s.consumers, nil)
+		return
+	case 1:
+		s.entity(w, r, s.consumers, p[0], nil)
+		return
+	}
+
+	consumer := s.consumers.get(p[0], nil)
+	if consumer == nil || len(p) > 3 {
+		notFound(w)
+		return
+	}
+
+	var t *table
+	switch p[1] {
+	case "acls":
+		t = s.acls
+	case "jwt":
+		t = s.jwts
+	case "key-auth":
+		t = s.keyAuths
+	case "basic-auth":
+		t = s.basicAuths
+	case "hmac-auth":
+		t = s.hmacAuths
+	default:
+		notFound(w)
+		return
+	}
+
+	scope := row{"consumer_id": consumer["id"]}
+	if len(p) == 2 {
+		s.collection(w, r, t, scope)
+	} else {
+		s.entity(w, r, t, p[2], scope)
+	}
+}
+
+// serveCredentials answers the read only requests below /acls, /jwts,
+// /key-auths and /basic-auths, which look up credentials of t across
+// consumers.
+func (s *Server) serveCredentials(w http.ResponseWriter, r *http.Request, t *table, p []string) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+
+	switch {
+	case len(p) == 0:
+		s.collection(w, r, t, nil)
+	case len(p) == 1:
+		s.entity(w, r, t, p[0], nil)
+	case len(p) == 2 && p[1] == "consumer":
+		credential := t.get(p[0], nil)
+		if credential == nil {
+			notFound(w)
+			return
+		}
+		s.entity(w, r, s.consumers, credential.str("consumer_id"), nil)
+	default:
+		notFound(w)
+	}
+}
+
+// servePlugins answers requests below /plugins.
+func (s *Server) servePlugins(w http.ResponseWriter, r *http.Request, p []string) {
+	switch {
+	case len(p) == 0:
+		s.collection(w, r, s.plugins, nil)
+	case len(p) == 1 && p[0] == "enabled":
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		respond(w, http.StatusOK, map[string]interface{}{"enabled_plugins": s.enabledPlugins()})
+	case len(p) == 1:
+		s.entity(w, r, s.plugins, p[0], nil)
+	default:
+		notFound(w)
+	}
+}
+
+// enabledPlugins lists the names of the plugins in use, as the fake
+// server accepts any plugin.
+func (s *Server) enabledPlugins() []string {
+	seen := make(map[string]bool)
+	names := []string{}
+	for _, p := range s.plugins.rows {
+		if name := p.str("name"); !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// serveUpstreams answers requests below /upstreams.
+func (s *Server) serveUpstreams(w http.ResponseWriter, r *http.Request, p []string) {
+	switch len(p) {
+	case 0:
+		s.collection(w, r, s.upstreams, nil)
+		return
+	case 1:
+		s.entity(w, r, s.upstreams, p[0], nil)
+		return
+	}
+
+	upstream := s.upstreams.get(p[0], nil)
+	if upstream == nil || p[1] != "targets" || len(p) > 3 {
+		notFound(w)
+		return
+	}
+
+	scope := row{"upstream_id": upstream["id"]}
+	switch {
+	case len(p) == 2 && r.Method == http.MethodGet:
+		// Kong lists the whole history of target entries, newest first
+		rows := s.targets.list(scope)
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+		s.page(w, r, filter(rows, r.URL.Query()))
+	case len(p) == 2:
+		s.collection(w, r, s.targets, scope)
+	case p[2] == "active":
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		s.page(w, r, s.activeTargets(scope))
+	default:
+		s.deleteTarget(w, r, p[2], scope)
+	}
+}
+
+// activeTargets returns the latest entry of every target of an upstream
+// whose weight is not zero, newest first.
+func (s *Server) activeTargets(scope row) []row {
+	var active []row
+	seen := make(map[string]bool)
+
+	rows := s.targets.list(scope)
+	for i := len(rows) - 1; i >= 0; i-- {
+		t := rows[i]
+		if seen[t.str("target")] {
+			continue
+		}
+		seen[t.str("target")] = true
+
+		if t["weight"] != float64(0) {
+			active = append(active, t)
+		}
+	}
+	return active
+}
+
+// deleteTarget disables a target the way Kong does, by adding an entry
+// with a weight of zero.
+func (s *Server) deleteTarget(w http.ResponseWriter, r *http.Request, target string, scope row) {
+	if r.Method != http.MethodDelete {
+		methodNotAllowed(w)
+		return
+	}
+
+	for _, t := range s.activeTargets(scope) {
+		if t.str("target") == target || t.str("id") == target {
+			entry := row{"target": t["target"], "weight": float64(0), "upstream_id": scope["upstream_id"]}
+			s.targets.insert(entry)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	notFound(w)
+}
+
+// checkPlugin verifies that the api and consumer a plugin applies to
+// exist.
+func (s *Server) checkPlugin(r row) fields {
+	errs := make(fields)
+	if id := r.str("api_id"); id != "" && s.apis.get(id, nil) == nil {
+		errs["api_id"] = fmt.Sprintf("no api with id '%v'", id)
+	}
+	if id := r.str("consumer_id"); id != "" && s.consumers.get(id, nil) == nil {
+		errs["consumer_id"] = fmt.Sprintf("no consumer with id '%v'", id)
+	}
+	return errs
+}
+
+// collection answers listing (GET) and creation (POST) requests on t,
+// restricted to the entities matching scope.
+func (s *Server) collection(w http.ResponseWriter, r *http.Request, t *table, scope row) {
+	switch r.Method {
+	case http.MethodGet:
+		s.page(w, r, filter(t.list(scope), r.URL.Query()))
+	case http.MethodPost:
+		body, ok := decode(w, r)
+		if !ok {
+			return
+		}
+		for k, v := range scope {
+			body[k] = v
+		}
+
+		if errs := t.validate(body); errs != nil {
+			schemaViolation(w, errs)
+			return
+		}
+		if errs := t.conflict(body); errs != nil {
+			uniqueViolation(w, errs)
+			return
+		}
+
+		t.insert(body)
+		respond(w, http.StatusCreated, body)
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+// entity answers requests on the entity of t identified by key.
+func (s *Server) entity(w http.ResponseWriter, r *http.Request, t *table, key string, scope row) {
+	e := t.get(key, scope)
+	if e == nil {
+		notFound(w)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		respond(w, http.StatusOK, e)
+	case http.MethodPatch:
+		body, ok := decode(w, r)
+		if !ok {
+			return
+		}
+
+		patched := merge(e, body)
+		for k, v := range scope {
+			patched[k] = v
+		}
+
+		if errs := t.validate(patched); errs != nil {
+			schemaViolation(w, errs)
+			return
+		}
+		if errs := t.conflict(patched); errs != nil {
+			uniqueViolation(w, errs)
+			return
+		}
+
+		for k := range e {
+			delete(e, k)
+		}
+		for k, v := range patched {
+			e[k] = v
+		}
+		respond(w, http.StatusOK, e)
+	case http.MethodDelete:
+		t.remove(e)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+// merge returns a copy of e updated with the fields of patch. Plugin
+// configs are merged rather than replaced, and the id and creation time
+// cannot be changed.
+func merge(e, patch row) row {
+	m := make(row)
+	for k, v := range e {
+		m[k] = v
+	}
+
+	for k, v := range patch {
+		if k == "id" || k == "created_at" {
+			continue
+		}
+
+		old, isMap := m[k].(map[string]interface{})
+		update, ok := v.(map[string]interface{})
+		if isMap && ok {
+			merged := make(map[string]interface{})
+			for ck, cv := range old {
+				merged[ck] = cv
+			}
+			for ck, cv := range update {
+				merged[ck] = cv
+			}
+			v = merged
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// filter returns the entities whose fields equal the values of the query
+// parameters, ignoring the pagination parameters.
+func filter(rows []row, query url.Values) []row {
+	var filtered []row
+	for _, r := range rows {
+		match := true
+		for k := range query {
+			if k == "size" || k == "offset" {
+				continue
+			}
+			if v, ok := r[k]; !ok || fmt.Sprint(v) != query.Get(k) {
+				match = false
+				break
+			}
+		}
+		if match {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// page answers a listing request with one page of rows, as selected by
+// the size and offset query parameters.
+func (s *Server) page(w http.ResponseWriter, r *http.Request, rows []row) {
+	query := r.URL.Query()
+
+	size := 100
+	if v := query.Get("size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 1000 {
+			schemaViolation(w, fields{"size": "must be an integer between 1 and 1000"})
+			return
+		}
+		size = n
+	}
+
+	start := 0
+	if v := query.Get("offset"); v != "" {
+		b, err := base64.RawURLEncoding.DecodeString(v)
+		n, aerr := strconv.Atoi(string(b))
+		if err != nil || aerr != nil || n < 0 || n > len(rows) {
+			respond(w, http.StatusBadRequest, map[string]interface{}{"message": "invalid offset"})
+			return
+		}
+		start = n
+	}
+
+	end := start + size
+	if end > len(rows) {
+		end = len(rows)
+	}
+
+	data := rows[start:end]
+	if data == nil {
+		data = []row{}
+	}
+	body := map[string]interface{}{"data": data, "total": len(rows)}
+
+	if end < len(rows) {
+		offset := base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(end)))
+		query.Set("offset", offset)
+		body["offset"] = offset
+		body["next"] = s.URL + r.URL.Path + "?" + query.Encode()
+	}
+
+	respond(w, http.StatusOK, body)
+}
+
+// decode reads the JSON object in the body of r. Fields set to null are
+// dropped, as Kong treats them as unset.
+func decode(w http.ResponseWriter, r *http.Request) (row, bool) {
+	body := make(row)
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err != io.EOF {
+		respond(w, http.StatusBadRequest, map[string]interface{}{"message": "Cannot parse JSON body"})
+		return nil, false
+	}
+
+	for k, v := range body {
+		if v == nil {
+			delete(body, k)
+		}
+	}
+	return body, true
+}
+
+func respond(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func notFound(w http.ResponseWriter) {
+	respond(w, http.StatusNotFound, map[string]interface{}{"message": "Not found"})
+}
+
+func methodNotAllowed(w http.ResponseWriter) {
+	respond(w, http.StatusMethodNotAllowed, map[string]interface{}{"message": "Method not allowed"})
+}
+
+func schemaViolation(w http.ResponseWriter, errs fields) {
+	var msgs []string
+	for f, msg := range errs {
+		msgs = append(msgs, fmt.Sprintf("%v: %v", f, msg))
+	}
+	sort.Strings(msgs)
+
+	respond(w, http.StatusBadRequest, map[string]interface{}{
+		"code":    2,
+		"name":    "schema violation",
+		"message": fmt.Sprintf("schema violation (%v)", strings.Join(msgs, "; ")),
+		"fields":  errs,
+	})
+}
+
+func uniqueViolation(w http.ResponseWriter, errs fields) {
+	var msgs []string
+	for f, v := range errs {
+		msgs = append(msgs, fmt.Sprintf("%v=%q", f, v))
+	}
+	sort.Strings(msgs)
+
+	respond(w, http.StatusConflict, map[string]interface{}{
+		"code":    5,
+		"name":    "unique constraint violation",
+		"message": fmt.Sprintf("UNIQUE violation detected on '{%v}'", strings.Join(msgs, ",")),
+		"fields":  errs,
+	})
+}
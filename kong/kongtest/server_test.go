@@ -0,0 +1,376 @@
+package kongtest
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nccurry/go-kong/kong"
+	"github.com/nccurry/go-kong/kong/state"
+)
+
+func TestServer_apis(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	api := &kong.ApiRequest{Name: "mockbin", UpstreamURL: "http://mockbin.org", Uris: []string{"/mockbin"}, StripUri: true}
+	if _, err := client.Apis.Post(api); err != nil {
+		t.Fatalf("Apis.Post returned error: %v", err)
+	}
+
+	got, _, err := client.Apis.Get("mockbin")
+	if err != nil {
+		t.Fatalf("Apis.Get returned error: %v", err)
+	}
+	if got.ID == "" || got.CreatedAt == 0 || got.UpstreamURL != "http://mockbin.org" {
+		t.Errorf("Apis.Get returned %+v, want stored api with id", got)
+	}
+
+	if _, _, err := client.Apis.Get(got.ID); err != nil {
+		t.Errorf("Apis.Get by id returned error: %v", err)
+	}
+
+	if _, err := client.Apis.Post(api); err == nil {
+		t.Error("Apis.Post of a duplicate returned no error")
+	} else if _, ok := err.(*kong.ConflictError); !ok {
+		t.Errorf("Apis.Post of a duplicate returned %T, want *kong.ConflictError", err)
+	}
+
+	api.UpstreamURL = "http://mockbin.com"
+	if _, err := client.Apis.Patch(api); err != nil {
+		t.Fatalf("Apis.Patch returned error: %v", err)
+	}
+	if got, _, _ := client.Apis.Get("mockbin"); got.UpstreamURL != "http://mockbin.com" {
+		t.Errorf("Apis.Patch left upstream_url %q", got.UpstreamURL)
+	}
+
+	if _, err := client.Apis.Delete("mockbin"); err != nil {
+		t.Fatalf("Apis.Delete returned error: %v", err)
+	}
+	if _, _, err := client.Apis.Get("mockbin"); err == nil {
+		t.Error("Apis.Get of a deleted api returned no error")
+	} else if _, ok := err.(*kong.NotFoundError); !ok {
+		t.Errorf("Apis.Get of a deleted api returned %T, want *kong.NotFoundError", err)
+	}
+}
+
+func TestServer_upsert(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	// The fake answers PUT on a collection with 405 Method Not Allowed, so
+	// Upsert falls back to POST and PATCH
+	consumer, result, _, err := client.Consumers.Upsert(&kong.Consumer{Username: "bob"})
+	if err != nil || result != kong.Created || consumer.ID == "" {
+		t.Fatalf("Consumers.Upsert returned %+v, %v, %v, want a created consumer", consumer, result, err)
+	}
+	if _, result, _, _ := client.Consumers.Upsert(&kong.Consumer{Username: "bob"}); result != kong.Unchanged {
+		t.Errorf("Consumers.Upsert of the same consumer returned %v, want unchanged", result)
+	}
+	updated, result, _, err := client.Consumers.Upsert(&kong.Consumer{Username: "bob", CustomID: "b"})
+	if err != nil || result != kong.Updated || updated.ID != consumer.ID || updated.CustomID != "b" {
+		t.Errorf("Consumers.Upsert returned %+v, %v, %v, want the consumer updated", updated, result, err)
+	}
+
+	acl := &kong.ConsumerACLConfig{Group: "admins"}
+	if _, result, _, err := client.Consumers.Plugins.ACL.Upsert("bob", acl); err != nil || result != kong.Created {
+		t.Errorf("ACL.Upsert returned %v, %v, want created", result, err)
+	}
+	if _, result, _, err := client.Consumers.Plugins.ACL.Upsert("bob", acl); err != nil || result != kong.Unchanged {
+		t.Errorf("ACL.Upsert of the same group returned %v, %v, want unchanged", result, err)
+	}
+
+	plugin := &kong.Plugin{Name: "cors", ConsumerID: consumer.ID, Config: map[string]interface{}{"max_age": 60}}
+	if _, result, _, err := client.Plugins.Upsert(plugin); err != nil || result != kong.Created {
+		t.Errorf("Plugins.Upsert returned %v, %v, want created", result, err)
+	}
+	if _, result, _, err := client.Plugins.Upsert(plugin); err != nil || result != kong.Unchanged {
+		t.Errorf("Plugins.Upsert of the same plugin returned %v, %v, want unchanged", result, err)
+	}
+	plugin.Config["max_age"] = 120
+	if got, result, _, err := client.Plugins.Upsert(plugin); err != nil || result != kong.Updated || got.Config["max_age"] != float64(120) {
+		t.Errorf("Plugins.Upsert returned %+v, %v, %v, want the config updated", got, result, err)
+	}
+
+	if plugins, _ := client.Plugins.ListAll(nil, 0); len(plugins) != 1 {
+		t.Errorf("Plugins.ListAll returned %d plugins, want 1", len(plugins))
+	}
+}
+
+func TestServer_schemaViolation(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	_, err := client.Apis.Post(&kong.ApiRequest{Name: "mockbin"})
+	resp, ok := err.(*kong.SchemaViolationError)
+	if !ok {
+		t.Fatalf("Apis.Post returned %v, want *kong.SchemaViolationError", err)
+	}
+	if resp.Response.StatusCode != http.StatusBadRequest || !strings.Contains(resp.KongMessage, "upstream_url") {
+		t.Errorf("Apis.Post returned %v, want 400 about upstream_url", err)
+	}
+	if len(resp.Fields) != 1 || resp.Fields[0].Field != "upstream_url" {
+		t.Errorf("Apis.Post returned fields %v, want upstream_url", resp.Fields)
+	}
+}
+
+func TestServer_plugins(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	client.Apis.Post(&kong.ApiRequest{Name: "mockbin", UpstreamURL: "http://mockbin.org"})
+	client.Consumers.Post(&kong.Consumer{Username: "bob"})
+	bob, _, _ := client.Consumers.Get("bob")
+
+	if _, err := client.Apis.Plugins.Post("mockbin", &kong.Plugin{Name: "acl", ConsumerID: bob.ID}); err != nil {
+		t.Fatalf("Apis.Plugins.Post returned error: %v", err)
+	}
+	if _, err := client.Apis.Plugins.Post("mockbin", &kong.Plugin{Name: "acl", ConsumerID: bob.ID}); err == nil {
+		t.Error("Apis.Plugins.Post of a duplicate returned no error")
+	}
+	if _, err := client.Plugins.Post(&kong.Plugin{Name: "cors"}); err != nil {
+		t.Fatalf("Plugins.Post returned error: %v", err)
+	}
+	if _, err := client.Plugins.Post(&kong.Plugin{Name: "cors", ConsumerID: "missing"}); err == nil {
+		t.Error("Plugins.Post for a missing consumer returned no error")
+	}
+
+	plugins, _, err := client.Apis.Plugins.GetAll("mockbin", nil)
+	if err != nil {
+		t.Fatalf("Apis.Plugins.GetAll returned error: %v", err)
+	}
+	if plugins.Total != 1 || plugins.Data[0].Name != "acl" || !*plugins.Data[0].Enabled {
+		t.Errorf("Apis.Plugins.GetAll returned %+v, want the enabled acl plugin", plugins.Data)
+	}
+
+	enabled, _, _ := client.Plugins.GetEnabled()
+	if got := strings.Join(enabled.Plugins, ","); got != "acl,cors" {
+		t.Errorf("Plugins.GetEnabled returned %q, want acl,cors", got)
+	}
+
+	// Deleting the consumer takes its plugins with it
+	client.Consumers.Delete("bob")
+	if all, _ := client.Plugins.ListAll(nil, 0); len(all) != 1 || all[0].Name != "cors" {
+		t.Errorf("Plugins.ListAll returned %d plugins after deleting the consumer, want cors only", len(all))
+	}
+}
+
+func TestServer_pagination(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		client.Consumers.Post(&kong.Consumer{Username: name})
+	}
+
+	page, _, err := client.Consumers.GetAll(&kong.ConsumersGetAllOptions{Size: 2})
+	if err != nil {
+		t.Fatalf("Consumers.GetAll returned error: %v", err)
+	}
+	if len(page.Data) != 2 || page.Total != 5 || page.Next == "" || page.Offset == "" {
+		t.Errorf("Consumers.GetAll returned %+v, want the first page of 2", page)
+	}
+
+	all, err := client.Consumers.ListAll(&kong.ConsumersGetAllOptions{Size: 2}, 0)
+	if err != nil {
+		t.Fatalf("Consumers.ListAll returned error: %v", err)
+	}
+	var names []string
+	for _, c := range all {
+		names = append(names, c.Username)
+	}
+	if got := strings.Join(names, ""); got != "abcde" {
+		t.Errorf("Consumers.ListAll returned %q, want abcde", got)
+	}
+
+	filtered, _, _ := client.Consumers.GetAll(&kong.ConsumersGetAllOptions{Username: "c"})
+	if len(filtered.Data) != 1 || filtered.Data[0].Username != "c" {
+		t.Errorf("Consumers.GetAll filtered on username returned %+v", filtered.Data)
+	}
+}
+
+func TestServer_credentials(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	client.Consumers.Post(&kong.Consumer{Username: "bob"})
+
+	keyAuth, _, err := client.Consumers.Plugins.KeyAuth.Post("bob", &kong.ConsumerKeyAuthConfig{})
+	if err != nil {
+		t.Fatalf("KeyAuth.Post returned error: %v", err)
+	}
+	if keyAuth.Key == "" || keyAuth.ConsumerID == "" {
+		t.Errorf("KeyAuth.Post returned %+v, want generated key", keyAuth)
+	}
+	if _, _, err := client.Consumers.Plugins.KeyAuth.Post("bob", &kong.ConsumerKeyAuthConfig{Key: keyAuth.Key}); err == nil {
+		t.Error("KeyAuth.Post of a duplicate key returned no error")
+	}
+
+	jwt, _, err := client.Consumers.Plugins.JWT.Post("bob", &kong.ConsumerJWTConfig{Key: "iss"})
+	if err != nil {
+		t.Fatalf("JWT.Post returned error: %v", err)
+	}
+	if jwt.Secret == "" || jwt.Algorithm != "HS256" {
+		t.Errorf("JWT.Post returned %+v, want generated secret", jwt)
+	}
+
+	client.Consumers.Plugins.ACL.Post("bob", &kong.ConsumerACLConfig{Group: "admins"})
+	acls, _, _ := client.Consumers.Plugins.ACL.GetAll("bob")
+	if len(acls.Data) != 1 || acls.Data[0].Group != "admins" {
+		t.Errorf("ACL.GetAll returned %+v, want admins", acls.Data)
+	}
+
+	if _, err := client.Consumers.Plugins.ACL.Delete("bob", acls.Data[0].ID); err != nil {
+		t.Errorf("ACL.Delete returned error: %v", err)
+	}
+	if _, err := client.Consumers.Plugins.ACL.Delete("bob", acls.Data[0].ID); err == nil {
+		t.Error("ACL.Delete of a deleted acl returned no error")
+	}
+
+	basicAuth, _, err := client.Consumers.Plugins.BasicAuth.Post("bob", &kong.ConsumerBasicAuthConfig{Username: "bob", Password: "s3cret"})
+	if err != nil {
+		t.Fatalf("BasicAuth.Post returned error: %v", err)
+	}
+	basicAuth.Password = "n3w"
+	if _, _, err := client.Consumers.Plugins.BasicAuth.Patch("bob", basicAuth); err != nil {
+		t.Errorf("BasicAuth.Patch returned error: %v", err)
+	}
+	if got, _, _ := client.Consumers.Plugins.BasicAuth.Get("bob", basicAuth.ID); got == nil || got.Password != "n3w" {
+		t.Errorf("BasicAuth.Get returned %+v, want the patched password", got)
+	}
+
+	hmacAuth, _, err := client.Consumers.Plugins.HMACAuth.Post("bob", &kong.ConsumerHMACAuthConfig{Username: "bob"})
+	if err != nil {
+		t.Fatalf("HMACAuth.Post returned error: %v", err)
+	}
+	if hmacAuth.Secret == "" {
+		t.Errorf("HMACAuth.Post returned %+v, want generated secret", hmacAuth)
+	}
+	if _, _, err := client.Consumers.Plugins.HMACAuth.Get("bob", "bob"); err != nil {
+		t.Errorf("HMACAuth.Get by username returned error: %v", err)
+	}
+
+	owner, _, err := client.KeyAuths.GetConsumer(keyAuth.Key)
+	if err != nil {
+		t.Fatalf("KeyAuths.GetConsumer returned error: %v", err)
+	}
+	if owner.Username != "bob" {
+		t.Errorf("KeyAuths.GetConsumer returned %+v, want bob", owner)
+	}
+	if found, _, _ := client.KeyAuths.GetAll(&kong.KeyAuthsGetAllOptions{Key: keyAuth.Key}); len(found.Data) != 1 {
+		t.Errorf("KeyAuths.GetAll filtered on key returned %+v, want one credential", found.Data)
+	}
+
+	if _, _, err := client.Consumers.Plugins.JWT.GetAll("alice"); err == nil {
+		t.Error("JWT.GetAll of a missing consumer returned no error")
+	}
+}
+
+func TestServer_targets(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	client.Upstreams.Post(&kong.Upstream{Name: "service.v1"})
+	client.Targets.Post("service.v1", &kong.Target{Target: "10.0.0.1:80"})
+	client.Targets.Post("service.v1", &kong.Target{Target: "10.0.0.2:80", Weight: 50})
+
+	if _, _, err := client.Targets.SetWeight("service.v1", "10.0.0.2:80", 20); err != nil {
+		t.Fatalf("Targets.SetWeight returned error: %v", err)
+	}
+	if _, err := client.Targets.Delete("service.v1", "10.0.0.1:80"); err != nil {
+		t.Fatalf("Targets.Delete returned error: %v", err)
+	}
+	if _, err := client.Targets.Delete("service.v1", "10.0.0.1:80"); err == nil {
+		t.Error("Targets.Delete of a deleted target returned no error")
+	}
+
+	active, _, err := client.Targets.GetAllActive("service.v1")
+	if err != nil {
+		t.Fatalf("Targets.GetAllActive returned error: %v", err)
+	}
+	if len(active.Data) != 1 || active.Data[0].Target != "10.0.0.2:80" || active.Data[0].Weight != 20 {
+		t.Errorf("Targets.GetAllActive returned %+v, want 10.0.0.2:80 at weight 20", active.Data)
+	}
+
+	history, _, _ := client.Targets.GetAll("service.v1", nil)
+	if history.Total != 4 {
+		t.Errorf("Targets.GetAll returned %d entries, want 4", history.Total)
+	}
+
+	client.Upstreams.Delete("service.v1")
+	if _, _, err := client.Targets.GetAllActive("service.v1"); err == nil {
+		t.Error("Targets.GetAllActive of a deleted upstream returned no error")
+	}
+}
+
+func TestServer_sync(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	desired, err := state.Load(strings.NewReader(`
+apis:
+- name: mockbin
+  upstream_url: http://mockbin.org
+  uris: ["/mockbin"]
+  plugins:
+  - name: rate-limiting
+    consumer: bob
+    config:
+      minute: 20
+consumers:
+- username: bob
+  acls:
+  - group: admins
+  jwts:
+  - key: iss
+    secret: s
+  key_auths:
+  - key: secret
+plugins:
+- name: correlation-id
+upstreams:
+- name: service.v1
+  targets:
+  - target: 10.0.0.1:80
+    weight: 50
+`))
+	if err != nil {
+		t.Fatalf("state.Load returned error: %v", err)
+	}
+
+	ctx := context.Background()
+	plan, err := state.Sync(ctx, client, desired, nil)
+	if err != nil {
+		t.Fatalf("state.Sync returned error: %v", err)
+	}
+	if len(plan.Changes) != 9 {
+		t.Errorf("state.Sync planned %d changes, want 9", len(plan.Changes))
+	}
+
+	// Once applied, the configuration is stable
+	plan, err = state.Sync(ctx, client, desired, nil)
+	if err != nil {
+		t.Fatalf("state.Sync returned error: %v", err)
+	}
+	if !plan.Empty() {
+		t.Errorf("second state.Sync planned %v, want no changes", plan.Changes)
+	}
+
+	// And removing everything from it empties Kong
+	if _, err := state.Sync(ctx, client, new(state.Config), nil); err != nil {
+		t.Fatalf("state.Sync returned error: %v", err)
+	}
+	current, _ := state.Fetch(ctx, client)
+	if len(current.Apis)+len(current.Consumers)+len(current.Plugins)+len(current.Upstreams) != 0 {
+		t.Errorf("state.Fetch returned %+v after removing everything", current)
+	}
+}
@@ -0,0 +1,181 @@
+package kongtest
+
+import (
+	"crypto/rand"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// row is a single entity, as decoded from its JSON representation.
+type row map[string]interface{}
+
+func (r row) str(field string) string {
+	s, _ := r[field].(string)
+	return s
+}
+
+// matches reports whether r holds every field of scope.
+func (r row) matches(scope row) bool {
+	for k, v := range scope {
+		if !reflect.DeepEqual(r[k], v) {
+			return false
+		}
+	}
+	return true
+}
+
+// ref points at the rows of a table which reference another entity
+// through field.
+type ref struct {
+	table *table
+	field string
+}
+
+// table holds the entities of one kind in creation order.
+type table struct {
+	keys     []string         // Fields besides id which identify an entity in urls, i.e. name.
+	unique   [][]string       // Sets of fields which must be unique together. Sets whose first field is unset are not checked.
+	required []string         // Fields which must be set on creation.
+	defaults func() row       // Values given to fields left unset on creation.
+	check    func(row) fields // Additional validation, returning the offending fields.
+	cascade  []ref            // Entities deleted along with an entity of this table.
+	rows     []row
+}
+
+// fields maps field names to what is wrong with them.
+type fields map[string]string
+
+// get returns the entity identified by key, its id or one of t.keys,
+// among those matching scope.
+func (t *table) get(key string, scope row) row {
+	for _, r := range t.rows {
+		if !r.matches(scope) {
+			continue
+		}
+		if r.str("id") == key {
+			return r
+		}
+		for _, k := range t.keys {
+			if r.str(k) == key {
+				return r
+			}
+		}
+	}
+	return nil
+}
+
+// list returns the entities matching scope.
+func (t *table) list(scope row) []row {
+	var rows []row
+	for _, r := range t.rows {
+		if r.matches(scope) {
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+// validate checks r against the schema of t, returning the offending
+// fields or nil.
+func (t *table) validate(r row) fields {
+	errs := make(fields)
+	for _, f := range t.required {
+		if v, ok := r[f]; !ok || v == "" {
+			errs[f] = "required field missing"
+		}
+	}
+	if t.check != nil {
+		for f, msg := range t.check(r) {
+			errs[f] = msg
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// conflict returns the fields r shares with another entity of t although
+// they must be unique, or nil.
+func (t *table) conflict(r row) fields {
+	for _, set := range t.unique {
+		if _, ok := r[set[0]]; !ok {
+			continue
+		}
+
+		for _, other := range t.rows {
+			if other.str("id") == r.str("id") {
+				continue
+			}
+
+			same := true
+			for _, f := range set {
+				same = same && reflect.DeepEqual(r[f], other[f])
+			}
+			if same {
+				errs := make(fields)
+				for _, f := range set {
+					errs[f] = fmt.Sprint(r[f])
+				}
+				return errs
+			}
+		}
+	}
+	return nil
+}
+
+// insert assigns r an id and creation time, fills in defaults and stores
+// it.
+func (t *table) insert(r row) {
+	if t.defaults != nil {
+		for k, v := range t.defaults() {
+			if _, ok := r[k]; !ok {
+				r[k] = v
+			}
+		}
+	}
+	r["id"] = newID()
+	r["created_at"] = float64(time.Now().UnixNano() / int64(time.Millisecond))
+
+	t.rows = append(t.rows, r)
+}
+
+// remove deletes r together with the entities referencing it.
+func (t *table) remove(r row) {
+	for i := range t.rows {
+		if t.rows[i].str("id") == r.str("id") {
+			t.rows = append(t.rows[:i], t.rows[i+1:]...)
+			break
+		}
+	}
+
+	for _, c := range t.cascade {
+		for _, child := range c.table.list(row{c.field: r["id"]}) {
+			c.table.remove(child)
+		}
+	}
+}
+
+// newID returns a random version 4 UUID, like the ones Kong assigns.
+func newID() string {
+	b := randomBytes(16)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// newKey returns a random credential, like the keys and secrets Kong
+// generates when none are given.
+func newKey() string {
+	return fmt.Sprintf("%x", randomBytes(16))
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return b
+}
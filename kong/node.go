@@ -1,6 +1,7 @@
 package kong
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -26,7 +27,12 @@ type Status struct {
 }
 
 func (s *NodeService) Get() (*Node, *http.Response, error) {
-	req, err := s.client.NewRequest("GET", "", nil)
+	return s.GetWithContext(context.Background())
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *NodeService) GetWithContext(ctx context.Context) (*Node, *http.Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, "GET", "", nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -41,7 +47,12 @@ func (s *NodeService) Get() (*Node, *http.Response, error) {
 }
 
 func (s *NodeService) GetStatus() (*Status, *http.Response, error) {
-	req, err := s.client.NewRequest("GET", "status", nil)
+	return s.GetStatusWithContext(context.Background())
+}
+
+// GetStatusWithContext is like GetStatus but uses ctx for the request.
+func (s *NodeService) GetStatusWithContext(ctx context.Context) (*Status, *http.Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, "GET", "status", nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -69,7 +80,12 @@ type ClusterMember struct {
 }
 
 func (s *ClusterService) Get() (*Cluster, *http.Response, error) {
-	req, err := s.client.NewRequest("GET", "cluster", nil)
+	return s.GetWithContext(context.Background())
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *ClusterService) GetWithContext(ctx context.Context) (*Cluster, *http.Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, "GET", "cluster", nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -84,7 +100,12 @@ func (s *ClusterService) Get() (*Cluster, *http.Response, error) {
 }
 
 func (s *ClusterService) Delete(clusterMember *ClusterMember) (*http.Response, error) {
-	req, err := s.client.NewRequest("DELETE", "cluster", clusterMember)
+	return s.DeleteWithContext(context.Background(), clusterMember)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *ClusterService) DeleteWithContext(ctx context.Context, clusterMember *ClusterMember) (*http.Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, "DELETE", "cluster", clusterMember)
 	if err != nil {
 		return nil, err
 	}
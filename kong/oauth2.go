@@ -0,0 +1,391 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ConsumersOAuth2Service handles communication with Kong's
+// '/consumers/{username or id}/oauth2' resource, the applications a
+// consumer registered with the oauth2 plugin.
+type ConsumersOAuth2Service service
+
+// ConsumerOAuth2Configs represents the object returned from Kong when
+// querying for the oauth2 applications of a consumer.
+type ConsumerOAuth2Configs struct {
+	Data   []*ConsumerOAuth2Config `json:"data,omitempty"`
+	Total  int                     `json:"total,omitempty"`
+	Next   string                  `json:"next,omitempty"`
+	Offset string                  `json:"offset,omitempty"`
+}
+
+// ConsumerOAuth2Config represents a single oauth2 application. Kong
+// generates ClientID and ClientSecret when they are left empty.
+type ConsumerOAuth2Config struct {
+	ConsumerID   string   `json:"consumer_id,omitempty"`
+	CreatedAt    int      `json:"created_at,omitempty"`
+	ID           string   `json:"id,omitempty"`
+	Name         string   `json:"name,omitempty"`
+	ClientID     string   `json:"client_id,omitempty"`
+	ClientSecret string   `json:"client_secret,omitempty"`
+	RedirectURIs []string `json:"redirect_uris,omitempty"`
+}
+
+// Post registers an oauth2 application for a consumer and returns it as
+// stored by Kong.
+//
+// Equivalent to POST /consumers/{username or id}/oauth2
+func (s *ConsumersOAuth2Service) Post(consumer string, config *ConsumerOAuth2Config) (*ConsumerOAuth2Config, *http.Response, error) {
+	return s.PostWithContext(context.Background(), consumer, config)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *ConsumersOAuth2Service) PostWithContext(ctx context.Context, consumer string, config *ConsumerOAuth2Config) (*ConsumerOAuth2Config, *http.Response, error) {
+	u := fmt.Sprintf("consumers/%v/oauth2", consumer)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, u, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerOAuth2Config)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// GetAll queries for the oauth2 applications of a consumer.
+//
+// Equivalent to GET /consumers/{username or id}/oauth2?uri=params&from=opt
+func (s *ConsumersOAuth2Service) GetAll(consumer string, opt *ConsumerCredentialsGetAllOptions) (*ConsumerOAuth2Configs, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), consumer, opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *ConsumersOAuth2Service) GetAllWithContext(ctx context.Context, consumer string, opt *ConsumerCredentialsGetAllOptions) (*ConsumerOAuth2Configs, *http.Response, error) {
+	u, err := addOptions(fmt.Sprintf("consumers/%v/oauth2", consumer), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerOAuth2Configs)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Get queries for a single oauth2 application of a consumer, by id or by
+// client_id.
+//
+// Equivalent to GET /consumers/{username or id}/oauth2/{client_id or id}
+func (s *ConsumersOAuth2Service) Get(consumer, id string) (*ConsumerOAuth2Config, *http.Response, error) {
+	return s.GetWithContext(context.Background(), consumer, id)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *ConsumersOAuth2Service) GetWithContext(ctx context.Context, consumer, id string) (*ConsumerOAuth2Config, *http.Response, error) {
+	u := fmt.Sprintf("consumers/%v/oauth2/%v", consumer, id)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerOAuth2Config)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Patch updates an oauth2 application of a consumer, i.e. to change its
+// redirect uris or rotate its client secret, and returns it as stored by
+// Kong. config.ID must be specified.
+//
+// Equivalent to PATCH /consumers/{username or id}/oauth2/{id}
+func (s *ConsumersOAuth2Service) Patch(consumer string, config *ConsumerOAuth2Config) (*ConsumerOAuth2Config, *http.Response, error) {
+	return s.PatchWithContext(context.Background(), consumer, config)
+}
+
+// PatchWithContext is like Patch but uses ctx for the request.
+func (s *ConsumersOAuth2Service) PatchWithContext(ctx context.Context, consumer string, config *ConsumerOAuth2Config) (*ConsumerOAuth2Config, *http.Response, error) {
+	if config.ID == "" {
+		return nil, nil, errors.New("config.ID must be specified")
+	}
+
+	u := fmt.Sprintf("consumers/%v/oauth2/%v", consumer, config.ID)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, u, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(ConsumerOAuth2Config)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Delete deletes a single oauth2 application of a consumer, by id or by
+// client_id. Kong deletes the tokens issued to it along with it.
+//
+// Equivalent to DELETE /consumers/{username or id}/oauth2/{client_id or id}
+func (s *ConsumersOAuth2Service) Delete(consumer, id string) (*http.Response, error) {
+	return s.DeleteWithContext(context.Background(), consumer, id)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *ConsumersOAuth2Service) DeleteWithContext(ctx context.Context, consumer, id string) (*http.Response, error) {
+	u := fmt.Sprintf("consumers/%v/oauth2/%v", consumer, id)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+
+	return resp, err
+}
+
+// Upsert creates the oauth2 credential of consumer identified by
+// config.ClientID or config.ID, or updates it to match config. It reports
+// whether the credential was created, updated or already matched.
+//
+// Equivalent to PUT /consumers/{username or id}/oauth2, or to POST or PATCH
+// when Kong does not accept PUT
+func (s *ConsumersOAuth2Service) Upsert(consumer string, config *ConsumerOAuth2Config) (*ConsumerOAuth2Config, UpsertResult, *http.Response, error) {
+	return s.UpsertWithContext(context.Background(), consumer, config)
+}
+
+// UpsertWithContext is like Upsert but uses ctx for the requests.
+func (s *ConsumersOAuth2Service) UpsertWithContext(ctx context.Context, consumer string, config *ConsumerOAuth2Config) (*ConsumerOAuth2Config, UpsertResult, *http.Response, error) {
+	key := config.ClientID
+	if key == "" {
+		key = config.ID
+	}
+	if key == "" {
+		return nil, Unchanged, nil, errors.New("At least one of config.ClientID or config.ID must be specified")
+	}
+
+	u := fmt.Sprintf("consumers/%v/oauth2", consumer)
+
+	return upsert(ctx, (*service)(s), u, config, func(ctx context.Context) (*ConsumerOAuth2Config, *http.Response, error) {
+		return found(s.GetWithContext(ctx, consumer, key))
+	})
+}
+
+// OAuth2TokensService handles communication with Kong's '/oauth2_tokens'
+// resource, the tokens issued by the oauth2 plugin.
+type OAuth2TokensService struct {
+	*service
+}
+
+// OAuth2Tokens represents the object returned from Kong when querying for
+// multiple oauth2 token objects.
+//
+// In cases where the number of objects returned exceeds the maximum,
+// Next holds the URI for the next set of results.
+// i.e. "http://localhost:8001/oauth2_tokens?offset=WyJmYjU3MjA1Ni1mODY1LTQ3"
+type OAuth2Tokens struct {
+	Data   []*OAuth2Token `json:"data,omitempty"`
+	Total  int            `json:"total,omitempty"`
+	Next   string         `json:"next,omitempty"`
+	Offset string         `json:"offset,omitempty"`
+}
+
+// OAuth2Token represents a single oauth2 token, issued to the application
+// identified by CredentialID. ExpiresIn is in seconds, 0 meaning the token
+// never expires.
+//
+// Tokens of plugins configured on an api hold ApiID, those of plugins
+// configured on a service ServiceID.
+type OAuth2Token struct {
+	ID                  string `json:"id,omitempty"`
+	CreatedAt           int    `json:"created_at,omitempty"`
+	CredentialID        string `json:"credential_id,omitempty"`
+	ApiID               string `json:"api_id,omitempty"`
+	ServiceID           string `json:"service_id,omitempty"`
+	TokenType           string `json:"token_type,omitempty"`
+	AccessToken         string `json:"access_token,omitempty"`
+	RefreshToken        string `json:"refresh_token,omitempty"`
+	ExpiresIn           int    `json:"expires_in,omitempty"`
+	Scope               string `json:"scope,omitempty"`
+	AuthenticatedUserID string `json:"authenticated_userid,omitempty"`
+}
+
+// OAuth2TokensGetAllOptions specifies optional filter parameters to the
+// OAuth2TokensService.GetAll method.
+//
+// Additional information about filtering options can be found in
+// the Kong documentation at:
+// https://getkong.org/plugins/oauth2-authentication/
+type OAuth2TokensGetAllOptions struct {
+	CredentialID        string `url:"credential_id,omitempty"`        // A filter on the list based on the credential_id field.
+	AuthenticatedUserID string `url:"authenticated_userid,omitempty"` // A filter on the list based on the authenticated_userid field.
+	Size                int    `url:"size,omitempty"`                 // A limit on the number of objects to be returned.
+	Offset              string `url:"offset,omitempty"`               // A cursor used for pagination. offset is an object identifier that defines a place in the list.
+}
+
+// Get queries for a single oauth2 token, by id or by its access token.
+//
+// Equivalent to GET /oauth2_tokens/{access_token or id}
+func (s *OAuth2TokensService) Get(token string) (*OAuth2Token, *http.Response, error) {
+	return s.GetWithContext(context.Background(), token)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *OAuth2TokensService) GetWithContext(ctx context.Context, token string) (*OAuth2Token, *http.Response, error) {
+	u := fmt.Sprintf("oauth2_tokens/%v", token)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(OAuth2Token)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// GetAll queries for all oauth2 tokens.
+// This query can be filtered by supplying the OAuth2TokensGetAllOptions struct.
+//
+// Equivalent to GET /oauth2_tokens?uri=params&from=opt
+func (s *OAuth2TokensService) GetAll(opt *OAuth2TokensGetAllOptions) (*OAuth2Tokens, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *OAuth2TokensService) GetAllWithContext(ctx context.Context, opt *OAuth2TokensGetAllOptions) (*OAuth2Tokens, *http.Response, error) {
+	u, err := addOptions("oauth2_tokens", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokens := new(OAuth2Tokens)
+	resp, err := s.client.Do(req, tokens)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return tokens, resp, err
+}
+
+// OAuth2TokensService.Iterator returns an Iterator over all
+// oauth2 tokens. opt.Size sets the page size and opt.Offset the starting
+// point, the remaining fields filter the results as in GetAll.
+func (s *OAuth2TokensService) Iterator(opt *OAuth2TokensGetAllOptions) *Iterator[OAuth2Token] {
+	return s.IteratorWithContext(context.Background(), opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *OAuth2TokensService) IteratorWithContext(ctx context.Context, opt *OAuth2TokensGetAllOptions) *Iterator[OAuth2Token] {
+	o := new(OAuth2TokensGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+
+	return newIterator(o.Offset, func(offset string) ([]*OAuth2Token, string, error) {
+		o.Offset = offset
+		tokens, _, err := s.GetAllWithContext(ctx, o)
+		if err != nil {
+			return nil, "", err
+		}
+		return tokens.Data, nextOffset(tokens.Next, tokens.Offset), nil
+	})
+}
+
+// OAuth2TokensService.ListAll follows Kong's pagination cursor and returns
+// every oauth2 token matching opt. If max is greater than zero no more
+// than max objects are returned.
+func (s *OAuth2TokensService) ListAll(opt *OAuth2TokensGetAllOptions, max int) ([]*OAuth2Token, error) {
+	return s.ListAllWithContext(context.Background(), opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *OAuth2TokensService) ListAllWithContext(ctx context.Context, opt *OAuth2TokensGetAllOptions, max int) ([]*OAuth2Token, error) {
+	return s.IteratorWithContext(ctx, opt).all(max)
+}
+
+// Post issues a token to an oauth2 application directly, bypassing the
+// authorization flow, and returns it as stored by Kong. token.CredentialID
+// must be specified. Kong generates AccessToken and RefreshToken when they
+// are left empty.
+//
+// This is mostly useful in tests and when migrating tokens from another
+// authorization server.
+//
+// Equivalent to POST /oauth2_tokens
+func (s *OAuth2TokensService) Post(token *OAuth2Token) (*OAuth2Token, *http.Response, error) {
+	return s.PostWithContext(context.Background(), token)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *OAuth2TokensService) PostWithContext(ctx context.Context, token *OAuth2Token) (*OAuth2Token, *http.Response, error) {
+	if token.CredentialID == "" {
+		return nil, nil, errors.New("token.CredentialID must be specified")
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, "oauth2_tokens", token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(OAuth2Token)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Revoke deletes a single oauth2 token, by id or by its access token, so
+// it is no longer accepted by Kong.
+//
+// Equivalent to DELETE /oauth2_tokens/{access_token or id}
+func (s *OAuth2TokensService) Revoke(token string) (*http.Response, error) {
+	return s.RevokeWithContext(context.Background(), token)
+}
+
+// RevokeWithContext is like Revoke but uses ctx for the request.
+func (s *OAuth2TokensService) RevokeWithContext(ctx context.Context, token string) (*http.Response, error) {
+	u := fmt.Sprintf("oauth2_tokens/%v", token)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+
+	return resp, err
+}
@@ -0,0 +1,197 @@
+package kong
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestConsumersOAuth2Service_Post(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/oauth2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"name":"app","redirect_uris":["https://example.com/cb"]}`+"\n")
+		fmt.Fprint(w, `{"id":"o1","name":"app","client_id":"cid","client_secret":"cs","redirect_uris":["https://example.com/cb"]}`)
+	})
+
+	input := &ConsumerOAuth2Config{Name: "app", RedirectURIs: []string{"https://example.com/cb"}}
+	app, _, err := client.Consumers.Plugins.OAuth2.Post("bob", input)
+	if err != nil {
+		t.Errorf("OAuth2.Post returned error: %v", err)
+	}
+
+	want := &ConsumerOAuth2Config{ID: "o1", Name: "app", ClientID: "cid", ClientSecret: "cs", RedirectURIs: []string{"https://example.com/cb"}}
+	if !reflect.DeepEqual(app, want) {
+		t.Errorf("OAuth2.Post returned %+v, want %+v", app, want)
+	}
+}
+
+func TestConsumersOAuth2Service_GetAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/oauth2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"size": "10"})
+		fmt.Fprint(w, `{"total":1,"data":[{"id":"o1"}]}`)
+	})
+
+	apps, _, err := client.Consumers.Plugins.OAuth2.GetAll("bob", &ConsumerCredentialsGetAllOptions{Size: 10})
+	if err != nil {
+		t.Errorf("OAuth2.GetAll returned error: %v", err)
+	}
+
+	want := &ConsumerOAuth2Configs{Total: 1, Data: []*ConsumerOAuth2Config{{ID: "o1"}}}
+	if !reflect.DeepEqual(apps, want) {
+		t.Errorf("OAuth2.GetAll returned %+v, want %+v", apps, want)
+	}
+}
+
+func TestConsumersOAuth2Service_Patch(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/oauth2/o1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"id":"o1","client_secret":"n3w"}`+"\n")
+		fmt.Fprint(w, `{"id":"o1","client_secret":"n3w"}`)
+	})
+
+	app, _, err := client.Consumers.Plugins.OAuth2.Patch("bob", &ConsumerOAuth2Config{ID: "o1", ClientSecret: "n3w"})
+	if err != nil {
+		t.Errorf("OAuth2.Patch returned error: %v", err)
+	}
+
+	want := &ConsumerOAuth2Config{ID: "o1", ClientSecret: "n3w"}
+	if !reflect.DeepEqual(app, want) {
+		t.Errorf("OAuth2.Patch returned %+v, want %+v", app, want)
+	}
+
+	if _, _, err := client.Consumers.Plugins.OAuth2.Patch("bob", &ConsumerOAuth2Config{}); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestConsumersOAuth2Service_Delete(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/oauth2/cid", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Consumers.Plugins.OAuth2.Delete("bob", "cid")
+	if err != nil {
+		t.Errorf("OAuth2.Delete returned error: %v", err)
+	}
+}
+
+func TestOAuth2TokensService_Get(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/oauth2_tokens/t1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"t1","credential_id":"o1","access_token":"at","token_type":"bearer","expires_in":7200}`)
+	})
+
+	token, _, err := client.OAuth2Tokens.Get("t1")
+	if err != nil {
+		t.Errorf("OAuth2Tokens.Get returned error: %v", err)
+	}
+
+	want := &OAuth2Token{ID: "t1", CredentialID: "o1", AccessToken: "at", TokenType: "bearer", ExpiresIn: 7200}
+	if !reflect.DeepEqual(token, want) {
+		t.Errorf("OAuth2Tokens.Get returned %+v, want %+v", token, want)
+	}
+}
+
+func TestOAuth2TokensService_GetAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/oauth2_tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"credential_id": "o1"})
+		fmt.Fprint(w, `{"total":1,"data":[{"id":"t1"}]}`)
+	})
+
+	tokens, _, err := client.OAuth2Tokens.GetAll(&OAuth2TokensGetAllOptions{CredentialID: "o1"})
+	if err != nil {
+		t.Errorf("OAuth2Tokens.GetAll returned error: %v", err)
+	}
+
+	want := &OAuth2Tokens{Total: 1, Data: []*OAuth2Token{{ID: "t1"}}}
+	if !reflect.DeepEqual(tokens, want) {
+		t.Errorf("OAuth2Tokens.GetAll returned %+v, want %+v", tokens, want)
+	}
+}
+
+func TestOAuth2TokensService_ListAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/oauth2_tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprint(w, `{"data":[{"id":"1"}],"total":2,"offset":"o"}`)
+		case "o":
+			fmt.Fprint(w, `{"data":[{"id":"2"}],"total":2}`)
+		}
+	})
+
+	tokens, err := client.OAuth2Tokens.ListAll(nil, 0)
+	if err != nil {
+		t.Errorf("OAuth2Tokens.ListAll returned error: %v", err)
+	}
+
+	want := []*OAuth2Token{{ID: "1"}, {ID: "2"}}
+	if !reflect.DeepEqual(tokens, want) {
+		t.Errorf("OAuth2Tokens.ListAll returned %+v, want %+v", tokens, want)
+	}
+}
+
+func TestOAuth2TokensService_Post(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/oauth2_tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"credential_id":"o1","expires_in":60}`+"\n")
+		fmt.Fprint(w, `{"id":"t1","credential_id":"o1","access_token":"at","expires_in":60}`)
+	})
+
+	token, _, err := client.OAuth2Tokens.Post(&OAuth2Token{CredentialID: "o1", ExpiresIn: 60})
+	if err != nil {
+		t.Errorf("OAuth2Tokens.Post returned error: %v", err)
+	}
+
+	want := &OAuth2Token{ID: "t1", CredentialID: "o1", AccessToken: "at", ExpiresIn: 60}
+	if !reflect.DeepEqual(token, want) {
+		t.Errorf("OAuth2Tokens.Post returned %+v, want %+v", token, want)
+	}
+
+	if _, _, err := client.OAuth2Tokens.Post(&OAuth2Token{}); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestOAuth2TokensService_Revoke(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/oauth2_tokens/at", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.OAuth2Tokens.Revoke("at")
+	if err != nil {
+		t.Errorf("OAuth2Tokens.Revoke returned error: %v", err)
+	}
+}
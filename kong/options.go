@@ -0,0 +1,165 @@
+package kong
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Option configures the Client built by NewClientWithOptions.
+type Option func(*clientOptions) error
+
+// clientOptions collects the options of NewClientWithOptions. Those
+// backed by a field of Client are set on client directly, while the
+// *http.Client is only assembled once every option has been applied, so
+// their order does not matter.
+type clientOptions struct {
+	client *Client
+
+	base      *http.Client
+	timeout   time.Duration
+	transport http.RoundTripper
+	tlsConfig *tls.Config
+}
+
+// httpClient returns the *http.Client described by o. The one passed to
+// WithHTTPClient is used as is unless other options change it, in which
+// case it is copied first.
+func (o *clientOptions) httpClient() (*http.Client, error) {
+	base := o.base
+	if base == nil {
+		base = http.DefaultClient
+	}
+	if o.timeout == 0 && o.transport == nil && o.tlsConfig == nil {
+		return base, nil
+	}
+
+	c := *base
+	if o.timeout != 0 {
+		c.Timeout = o.timeout
+	}
+	if o.transport != nil {
+		c.Transport = o.transport
+	}
+
+	if o.tlsConfig != nil {
+		rt := c.Transport
+		if rt == nil {
+			rt = http.DefaultTransport
+		}
+		t, ok := rt.(*http.Transport)
+		if !ok {
+			return nil, errors.New("WithTLSConfig requires the transport to be an *http.Transport")
+		}
+		t = t.Clone()
+		t.TLSClientConfig = o.tlsConfig
+		c.Transport = t
+	}
+
+	return &c, nil
+}
+
+// Logger is used by Client to log requests. It is satisfied by *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// logf logs a message prefixed with kong: if c has a Logger.
+func (c *Client) logf(format string, v ...interface{}) {
+	if c.Logger != nil {
+		c.Logger.Printf("kong: "+format, v...)
+	}
+}
+
+// WithHTTPClient sets the *http.Client used to send requests, instead of
+// http.DefaultClient. A nil httpClient leaves the default.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) error {
+		o.base = httpClient
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit of every attempt of a request,
+// including reading the response body.
+func WithTimeout(d time.Duration) Option {
+	return func(o *clientOptions) error {
+		if d < 0 {
+			return errors.New("timeout must not be negative")
+		}
+		o.timeout = d
+		return nil
+	}
+}
+
+// WithTransport sets the http.RoundTripper used to send requests.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(o *clientOptions) error {
+		o.transport = rt
+		return nil
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of the transport, i.e. one
+// returned by LoadTLSConfig for an Admin API served over mutual TLS. The
+// transport has to be an *http.Transport, which is cloned.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(o *clientOptions) error {
+		o.tlsConfig = config
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) error {
+		o.client.UserAgent = userAgent
+		return nil
+	}
+}
+
+// WithHeader adds a header sent with every request, such as the apikey
+// or Kong-Admin-Token of a protected Admin API.
+func WithHeader(key, value string) Option {
+	return func(o *clientOptions) error {
+		if o.client.Header == nil {
+			o.client.Header = make(http.Header)
+		}
+		o.client.Header.Add(key, value)
+		return nil
+	}
+}
+
+// WithBasicAuth sets the basic auth credentials sent with every request.
+func WithBasicAuth(username, password string) Option {
+	return func(o *clientOptions) error {
+		o.client.Username, o.client.Password = username, password
+		return nil
+	}
+}
+
+// WithSigner sets the RequestSigner called on every request.
+func WithSigner(signer RequestSigner) Option {
+	return func(o *clientOptions) error {
+		o.client.Signer = signer
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry requests failing with a
+// transient error.
+func WithRetryPolicy(p *RetryPolicy) Option {
+	return func(o *clientOptions) error {
+		o.client.RetryPolicy = p
+		return nil
+	}
+}
+
+// WithLogger sets the Logger used to log every request and retry.
+func WithLogger(l Logger) Option {
+	return func(o *clientOptions) error {
+		o.client.Logger = l
+		return nil
+	}
+}
@@ -0,0 +1,184 @@
+package kong
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewClientWithOptions_basePath(t *testing.T) {
+	tests := map[string]string{
+		"http://kong:8001":             "http://kong:8001/apis/a",
+		"http://kong:8001/":            "http://kong:8001/apis/a",
+		"https://example.com/admin":    "https://example.com/admin/apis/a",
+		"https://example.com/admin/":   "https://example.com/admin/apis/a",
+		"https://example.com/a%2Fb":    "https://example.com/a%2Fb/apis/a",
+		"https://example.com/admin?x=": "https://example.com/admin/apis/a",
+	}
+
+	for base, want := range tests {
+		c, err := NewClientWithOptions(base)
+		if err != nil {
+			t.Fatalf("NewClientWithOptions(%q) returned error: %v", base, err)
+		}
+
+		req, _ := c.NewRequest("GET", "apis/a", nil)
+		if got := req.URL.String(); got != want {
+			t.Errorf("NewClientWithOptions(%q) resolved apis/a to %v, want %v", base, got, want)
+		}
+	}
+}
+
+func TestNewClientWithOptions_default(t *testing.T) {
+	c, err := NewClientWithOptions("http://kong:8001/")
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned error: %v", err)
+	}
+	if c.client != http.DefaultClient {
+		t.Error("NewClientWithOptions did not use http.DefaultClient")
+	}
+	if c.RetryPolicy != nil || c.Logger != nil || c.Header != nil {
+		t.Errorf("NewClientWithOptions set options which were not given: %+v", c)
+	}
+}
+
+func TestNewClientWithOptions(t *testing.T) {
+	var sent *http.Request
+	rt := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		sent = req
+		return &http.Response{
+			StatusCode: 200,
+			Body:       http.NoBody,
+			Request:    req,
+		}, nil
+	})
+
+	policy := DefaultRetryPolicy()
+	base := &http.Client{}
+	c, err := NewClientWithOptions("http://kong:8001",
+		WithTimeout(time.Second),
+		WithHTTPClient(base),
+		WithTransport(rt),
+		WithUserAgent("deployer/1.0"),
+		WithHeader("Kong-Admin-Token", "t"),
+		WithBasicAuth("admin", "secret"),
+		WithRetryPolicy(policy),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned error: %v", err)
+	}
+
+	if c.client == base || base.Timeout != 0 || base.Transport != nil {
+		t.Error("NewClientWithOptions modified the *http.Client given")
+	}
+	if c.client.Timeout != time.Second {
+		t.Errorf("Timeout is %v, want 1s", c.client.Timeout)
+	}
+	if c.RetryPolicy != policy {
+		t.Error("RetryPolicy was not set")
+	}
+
+	if _, err := c.Apis.Delete("a"); err != nil {
+		t.Fatalf("Apis.Delete returned error: %v", err)
+	}
+	if sent == nil {
+		t.Fatal("The request was not sent through the transport")
+	}
+	if got := sent.Header.Get("User-Agent"); got != "deployer/1.0" {
+		t.Errorf("User-Agent header = %q, want %q", got, "deployer/1.0")
+	}
+	if got := sent.Header.Get("Kong-Admin-Token"); got != "t" {
+		t.Errorf("Kong-Admin-Token header = %q, want %q", got, "t")
+	}
+	if u, p, _ := sent.BasicAuth(); u != "admin" || p != "secret" {
+		t.Errorf("basic auth = %v, %v, want admin, secret", u, p)
+	}
+}
+
+func TestNewClientWithOptions_tlsConfig(t *testing.T) {
+	config := &tls.Config{ServerName: "kong"}
+	c, err := NewClientWithOptions("https://kong:8444/", WithTLSConfig(config))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned error: %v", err)
+	}
+
+	transport, ok := c.client.Transport.(*http.Transport)
+	if !ok || transport.TLSClientConfig != config {
+		t.Errorf("Transport is %#v, want an *http.Transport using the TLS config", c.client.Transport)
+	}
+	if transport == http.DefaultTransport {
+		t.Error("NewClientWithOptions modified http.DefaultTransport")
+	}
+
+	_, err = NewClientWithOptions("https://kong:8444/",
+		WithTransport(roundTripperFunc(nil)),
+		WithTLSConfig(config),
+	)
+	if err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestNewClientWithOptions_errors(t *testing.T) {
+	if _, err := NewClientWithOptions("%"); err == nil {
+		t.Error("Expected error to be returned for a bad url")
+	}
+	if _, err := NewClientWithOptions("http://kong:8001/", WithTimeout(-time.Second)); err == nil {
+		t.Error("Expected error to be returned for a negative timeout")
+	}
+}
+
+func TestNewClientWithOptions_logger(t *testing.T) {
+	s, _ := failingServer(1, http.StatusServiceUnavailable)
+	defer s.Close()
+
+	var buf bytes.Buffer
+	policy := DefaultRetryPolicy()
+	policy.Backoff = noBackoff
+	c, _ := NewClientWithOptions(s.URL,
+		WithLogger(log.New(&buf, "", 0)),
+		WithRetryPolicy(policy),
+	)
+
+	if _, _, err := c.Apis.Get("i"); err != nil {
+		t.Fatalf("Apis.Get returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Logged %q, want two lines", buf.String())
+	}
+	if want := fmt.Sprintf("kong: GET %v/apis/i: 503, retrying in 0s", s.URL); lines[0] != want {
+		t.Errorf("Logged %q, want %q", lines[0], want)
+	}
+	if want := fmt.Sprintf("kong: GET %v/apis/i: 200 in ", s.URL); !strings.HasPrefix(lines[1], want) {
+		t.Errorf("Logged %q, want it to start with %q", lines[1], want)
+	}
+}
+
+func TestNewClientWithOptions_loggerError(t *testing.T) {
+	s := httptest.NewServer(http.NotFoundHandler())
+	s.Close()
+
+	var buf bytes.Buffer
+	c, _ := NewClientWithOptions(s.URL, WithLogger(log.New(&buf, "", 0)))
+	if _, _, err := c.Apis.Get("i"); err == nil {
+		t.Fatal("Expected error to be returned")
+	}
+
+	if want := fmt.Sprintf("kong: GET %v/apis/i: ", s.URL); !strings.HasPrefix(buf.String(), want) {
+		t.Errorf("Logged %q, want it to start with %q", buf.String(), want)
+	}
+}
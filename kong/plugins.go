@@ -1,6 +1,8 @@
 package kong
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/fatih/structs"
@@ -19,9 +21,10 @@ type PluginsService service
 // Next holds the URI for the next set of results.
 // i.e. "http://localhost:8001/plugins?size=2&offset=4d924084-1adb-40a5-c042-63b19db421d1"
 type Plugins struct {
-	Data  []*Plugin `json:"data,omitempty"`
-	Total int       `json:"total,omitempty"`
-	Next  string    `json:"next,omitempty"`
+	Data   []*Plugin `json:"data,omitempty"`
+	Total  int       `json:"total,omitempty"`
+	Next   string    `json:"next,omitempty"`
+	Offset string    `json:"offset,omitempty"`
 }
 
 // Plugin represents a single Kong plugin object.
@@ -37,6 +40,8 @@ type Plugin struct {
 	CreatedAt  int                    `json:"created_at,omitempty"`
 	Enabled    *bool                  `json:"enabled,omitempty"`
 	ApiID      string                 `json:"api_id,omitempty"`
+	ServiceID  string                 `json:"service_id,omitempty"`
+	RouteID    string                 `json:"route_id,omitempty"`
 	ConsumerID string                 `json:"consumer_id,omitempty"`
 	Config     map[string]interface{} `json:"config,omitempty"`
 }
@@ -45,9 +50,14 @@ type Plugin struct {
 //
 // Equivalent to GET /plugins/{id}
 func (s *PluginsService) Get(id string) (*Plugin, *http.Response, error) {
+	return s.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *PluginsService) GetWithContext(ctx context.Context, id string) (*Plugin, *http.Response, error) {
 	u := fmt.Sprintf("plugins/%v", id)
 
-	req, err := s.client.NewRequest("GET", u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -70,7 +80,12 @@ type EnabledPlugins struct {
 // PluginsService.GetEnabled queries for the list of all enabled
 // Kong plugins.
 func (s *PluginsService) GetEnabled() (*EnabledPlugins, *http.Response, error) {
-	req, err := s.client.NewRequest("GET", "plugins/enabled", nil)
+	return s.GetEnabledWithContext(context.Background())
+}
+
+// GetEnabledWithContext is like GetEnabled but uses ctx for the request.
+func (s *PluginsService) GetEnabledWithContext(ctx context.Context) (*EnabledPlugins, *http.Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, "GET", "plugins/enabled", nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -85,20 +100,33 @@ func (s *PluginsService) GetEnabled() (*EnabledPlugins, *http.Response, error) {
 }
 
 // PluginsService.Patch updates an existing Kong plugin object for
-// a specific api. Accepts either api name or id.
+// a specific api. Accepts either api name or id. Use UpdateForApi to get
+// the updated object back.
 //
 // Equivalent to PATCH /apis/{name or id}/plugins/{id}
 func (s *PluginsService) Patch(api string, plugin *Plugin) (*http.Response, error) {
-	u := fmt.Sprintf("apis/%v/plugins/%v", api, plugin.ID)
+	return s.PatchWithContext(context.Background(), api, plugin)
+}
 
-	req, err := s.client.NewRequest("PATCH", u, plugin)
-	if err != nil {
-		return nil, err
-	}
+// PatchWithContext is like Patch but uses ctx for the request.
+func (s *PluginsService) PatchWithContext(ctx context.Context, api string, plugin *Plugin) (*http.Response, error) {
+	_, resp, err := s.UpdateForApiWithContext(ctx, api, plugin)
+	return resp, err
+}
 
-	resp, err := s.client.Do(req, nil)
+// PluginsService.UpdateForApi is like Patch but returns the updated Kong
+// plugin object. Unlike Update it goes through the api the plugin is
+// applied to, which is the only path older Kong versions expose.
+//
+// Equivalent to PATCH /apis/{name or id}/plugins/{id}
+func (s *PluginsService) UpdateForApi(api string, plugin *Plugin) (*Plugin, *http.Response, error) {
+	return s.UpdateForApiWithContext(context.Background(), api, plugin)
+}
 
-	return resp, err
+// UpdateForApiWithContext is like UpdateForApi but uses ctx for the
+// request.
+func (s *PluginsService) UpdateForApiWithContext(ctx context.Context, api string, plugin *Plugin) (*Plugin, *http.Response, error) {
+	return (*ApisPluginsService)(s).UpdateWithContext(ctx, api, plugin)
 }
 
 // PluginsService.Delete deletes a single Kong plugin object attached
@@ -107,9 +135,14 @@ func (s *PluginsService) Patch(api string, plugin *Plugin) (*http.Response, erro
 //
 // Equivalent to DELETE /apis/{name or id}/plugins/{id}
 func (s *PluginsService) Delete(api string, plugin string) (*http.Response, error) {
+	return s.DeleteWithContext(context.Background(), api, plugin)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *PluginsService) DeleteWithContext(ctx context.Context, api string, plugin string) (*http.Response, error) {
 	u := fmt.Sprintf("apis/%v/plugins/%v", api, plugin)
 
-	req, err := s.client.NewRequest("DELETE", u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "DELETE", u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -125,19 +158,122 @@ func (s *PluginsService) Delete(api string, plugin string) (*http.Response, erro
 // PluginsService.Post creates a new Kong plugin object.
 // Which consumer and api objects the plugin gets applied to
 // depend on the values of ConsumerID and ApiID on the
-// passed plugin object.
+// passed plugin object. Use Create to get the created object back.
 //
 // For more info see:
 // https://getkong.org/docs/0.9.x/admin-api/#add-plugin
 func (s *PluginsService) Post(plugin *Plugin) (*http.Response, error) {
-	req, err := s.client.NewRequest("POST", "plugins", plugin)
+	return s.PostWithContext(context.Background(), plugin)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *PluginsService) PostWithContext(ctx context.Context, plugin *Plugin) (*http.Response, error) {
+	_, resp, err := s.CreateWithContext(ctx, plugin)
+	return resp, err
+}
+
+// PluginsService.Create is like Post but returns the created Kong plugin
+// object, including its id and the config defaults Kong filled in.
+//
+// Equivalent to POST /plugins
+func (s *PluginsService) Create(plugin *Plugin) (*Plugin, *http.Response, error) {
+	return s.CreateWithContext(context.Background(), plugin)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *PluginsService) CreateWithContext(ctx context.Context, plugin *Plugin) (*Plugin, *http.Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, "plugins", plugin)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	resp, err := s.client.Do(req, nil)
+	uResp := new(Plugin)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return resp, err
+	return uResp, resp, err
+}
+
+// PluginsService.Update updates an existing Kong plugin object, wherever
+// it is applied, and returns the updated object. plugin.ID must be
+// specified.
+//
+// Equivalent to PATCH /plugins/{id}
+func (s *PluginsService) Update(plugin *Plugin) (*Plugin, *http.Response, error) {
+	return s.UpdateWithContext(context.Background(), plugin)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *PluginsService) UpdateWithContext(ctx context.Context, plugin *Plugin) (*Plugin, *http.Response, error) {
+	if plugin.ID == "" {
+		return nil, nil, errors.New("plugin.ID must be specified")
+	}
+
+	u := fmt.Sprintf("plugins/%v", plugin.ID)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, u, plugin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Plugin)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Upsert creates the Kong plugin object identified by
+// plugin.ID or, without one, the plugin named plugin.Name applied to
+// exactly the api, service, route and consumer plugin is, or updates it
+// to match plugin. Config is compared field by field, so the defaults
+// Kong filled in don't count as a change. It reports whether the plugin
+// was created, updated or already matched.
+//
+// Equivalent to PUT /plugins, or to POST /plugins or PATCH /plugins/{id}
+// when Kong does not accept PUT
+func (s *PluginsService) Upsert(plugin *Plugin) (*Plugin, UpsertResult, *http.Response, error) {
+	return s.UpsertWithContext(context.Background(), plugin)
+}
+
+// UpsertWithContext is like Upsert but uses ctx for the requests.
+func (s *PluginsService) UpsertWithContext(ctx context.Context, plugin *Plugin) (*Plugin, UpsertResult, *http.Response, error) {
+	find := func(ctx context.Context) (*Plugin, *http.Response, error) {
+		if plugin.ID != "" {
+			return found(s.GetWithContext(ctx, plugin.ID))
+		}
+
+		plugins, err := s.ListAllWithContext(ctx, &PluginsGetAllOptions{
+			Name:       plugin.Name,
+			ApiID:      plugin.ApiID,
+			ServiceID:  plugin.ServiceID,
+			RouteID:    plugin.RouteID,
+			ConsumerID: plugin.ConsumerID,
+		}, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Unset filters match any value, while an unset field on plugin
+		// means the plugin isn't scoped that way
+		for _, p := range plugins {
+			if p.ApiID == plugin.ApiID && p.ServiceID == plugin.ServiceID &&
+				p.RouteID == plugin.RouteID && p.ConsumerID == plugin.ConsumerID {
+				return p, nil, nil
+			}
+		}
+		return nil, nil, nil
+	}
+
+	if plugin.ID == "" && plugin.Name == "" {
+		return nil, Unchanged, nil, errors.New("At least one of plugin.ID or plugin.Name must be specified")
+	}
+
+	return upsert(ctx, (*service)(s), "plugins", plugin, find)
 }
 
 // PluginsGetAllOptions specifies optional filter parameters
@@ -150,6 +286,8 @@ type PluginsGetAllOptions struct {
 	ID         string `url:"id,omitempty"`          // A filter on the list based on the id field.
 	Name       string `url:"name,omitempty"`        // A filter on the list based on the name field.
 	ApiID      string `url:"api_id,omitempty"`      // A filter on the list based on the api_id field.
+	ServiceID  string `url:"service_id,omitempty"`  // A filter on the list based on the service_id field.
+	RouteID    string `url:"route_id,omitempty"`    // A filter on the list based on the route_id field.
 	ConsumerID string `url:"consumer_id,omitempty"` // A filter on the list based on the consumer_id field.
 	Size       int    `url:"size,omitempty"`        // A limit on the number of objects to be returned.
 	Offset     string `url:"offset,omitempty"`      // A cursor used for pagination. offset is an object identifier that defines a place in the list.
@@ -161,12 +299,17 @@ type PluginsGetAllOptions struct {
 //
 // Equivalent to GET /plugins?uri=params&from=opt
 func (s *PluginsService) GetAll(opt *PluginsGetAllOptions) (*Plugins, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *PluginsService) GetAllWithContext(ctx context.Context, opt *PluginsGetAllOptions) (*Plugins, *http.Response, error) {
 	u, err := addOptions("plugins", opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest("GET", u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -180,14 +323,64 @@ func (s *PluginsService) GetAll(opt *PluginsGetAllOptions) (*Plugins, *http.Resp
 	return plugins, resp, err
 }
 
+// newPluginsIterator builds an Iterator on top of getAll, which
+// is shared between the '/plugins' and '/apis/{api}/plugins' listings.
+func newPluginsIterator(opt *PluginsGetAllOptions, getAll func(*PluginsGetAllOptions) (*Plugins, error)) *Iterator[Plugin] {
+	o := new(PluginsGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+
+	return newIterator(o.Offset, func(offset string) ([]*Plugin, string, error) {
+		o.Offset = offset
+		plugins, err := getAll(o)
+		if err != nil {
+			return nil, "", err
+		}
+		return plugins.Data, nextOffset(plugins.Next, plugins.Offset), nil
+	})
+}
+
+// PluginsService.Iterator returns an Iterator over all Kong plugin
+// objects. opt.Size sets the page size and opt.Offset the starting point,
+// the remaining fields filter the results as in GetAll.
+func (s *PluginsService) Iterator(opt *PluginsGetAllOptions) *Iterator[Plugin] {
+	return s.IteratorWithContext(context.Background(), opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *PluginsService) IteratorWithContext(ctx context.Context, opt *PluginsGetAllOptions) *Iterator[Plugin] {
+	return newPluginsIterator(opt, func(o *PluginsGetAllOptions) (*Plugins, error) {
+		plugins, _, err := s.GetAllWithContext(ctx, o)
+		return plugins, err
+	})
+}
+
+// PluginsService.ListAll follows Kong's pagination cursor and returns every
+// plugin object matching opt. If max is greater than zero no more than max
+// objects are returned.
+func (s *PluginsService) ListAll(opt *PluginsGetAllOptions, max int) ([]*Plugin, error) {
+	return s.ListAllWithContext(context.Background(), opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *PluginsService) ListAllWithContext(ctx context.Context, opt *PluginsGetAllOptions, max int) ([]*Plugin, error) {
+	return s.IteratorWithContext(ctx, opt).all(max)
+}
+
 // PluginsService.GetSchema queries for the schema of a particular
 // Kong plugin.
 //
 // Equivalent to GET /plugins/schema/{name}
 func (s *PluginsService) GetSchema(name string) (map[string]interface{}, *http.Response, error) {
+	return s.GetSchemaWithContext(context.Background(), name)
+}
+
+// GetSchemaWithContext is like GetSchema but uses ctx for the request.
+func (s *PluginsService) GetSchemaWithContext(ctx context.Context, name string) (map[string]interface{}, *http.Response, error) {
 	u := fmt.Sprintf("plugins/schema/%v", name)
 
-	req, err := s.client.NewRequest("GET", u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -260,7 +453,12 @@ func ToMap(config interface{}) map[string]interface{} {
 		// isZero checks whether the value of the field v is 'zero'
 		// If it is, we do not need to add it to our map[string]interface{}
 		// Doing so causes errors later when we try to marshal to JSON
-		if ok := isZero(reflect.ValueOf(s.Field(v).Value())); !ok {
+		// Pointers such as *bool are set deliberately, even to false,
+		// so only nil ones are skipped and the rest are dereferenced
+		fv := reflect.ValueOf(s.Field(v).Value())
+		if fv.Kind() == reflect.Ptr && !fv.IsNil() {
+			c[tags[0]] = fv.Elem().Interface()
+		} else if ok := isZero(fv); !ok {
 			c[tags[0]] = s.Field(v).Value()
 		}
 	}
@@ -309,6 +507,11 @@ func SetJSONField(configStruct interface{}, mapKey string, mapValue interface{})
 		return fmt.Errorf("Cannot set %s field mapValue", mapKey)
 	}
 
+	// Kong 1.x reports unset fields as null, which leaves them unset here
+	if mapValue == nil {
+		return nil
+	}
+
 	// TODO: Take a harder look at this problem and make sure there isn't a cleaner way to convert interface{} to struct field
 	mv := reflect.ValueOf(mapValue)
 	switch sfv.Kind() {
@@ -318,13 +521,43 @@ func SetJSONField(configStruct interface{}, mapKey string, mapValue interface{})
 		}
 		sfv.Set(mv)
 	case reflect.Int:
-		if mv.Kind() != reflect.Float64 {
+		// Numbers decoded from JSON are float64, those from ToMap int
+		switch mv.Kind() {
+		case reflect.Float64:
+			sfv.Set(reflect.ValueOf(int(mv.Float())))
+		case reflect.Int:
+			sfv.Set(mv)
+		default:
+			return fmt.Errorf("Provided mapValue type didn't match configStruct field type. Got %v, want %v", mv.Type(), sfv.Type())
+		}
+	case reflect.Bool:
+		if mv.Kind() != reflect.Bool {
+			return fmt.Errorf("Provided mapValue type didn't match configStruct field type. Got %v, want %v", mv.Type(), sfv.Type())
+		}
+		sfv.SetBool(mv.Bool())
+	case reflect.Ptr:
+		if sfv.Type().Elem().Kind() != reflect.Bool {
+			return setJSONValue(sfv, mapKey, mapValue)
+		}
+		if mv.Kind() != reflect.Bool {
 			return fmt.Errorf("Provided mapValue type didn't match configStruct field type. Got %v, want %v", mv.Type(), sfv.Type())
 		}
-		sfv.Set(reflect.ValueOf(int(mv.Float())))
+		b := mv.Bool()
+		sfv.Set(reflect.ValueOf(&b))
 	case reflect.Slice:
+		if sfv.Type().Elem().Kind() != reflect.String {
+			return setJSONValue(sfv, mapKey, mapValue)
+		}
+		if v, ok := mapValue.([]string); ok {
+			sfv.Set(reflect.ValueOf(v))
+			break
+		}
+		vs, ok := mapValue.([]interface{})
+		if !ok {
+			return errors.New("Provided mapValue type didn't match configStruct field type. Need []string")
+		}
 		var s []string
-		for _, v := range mv.Interface().([]interface{}) {
+		for _, v := range vs {
 			val, ok := v.(string)
 			if !ok {
 				return errors.New("Provided mapValue type didn't match configStruct field type. Need []string")
@@ -332,10 +565,30 @@ func SetJSONField(configStruct interface{}, mapKey string, mapValue interface{})
 			s = append(s, val)
 		}
 		sfv.Set(reflect.ValueOf(s))
+	case reflect.Float64, reflect.Map, reflect.Struct:
+		return setJSONValue(sfv, mapKey, mapValue)
 	default:
-		return errors.New("Provided mapValue type was not expected. mapValue can only be of types string, int, []string")
+		return errors.New("Provided mapValue type was not expected. mapValue can only be of types string, int, float64, bool, []string, or nested records and maps")
+	}
+
+	return nil
+}
+
+// setJSONValue sets sfv to mapValue by way of its JSON encoding. It covers
+// the nested records, lists of records and maps some plugins configure,
+// i.e. the limits of response-ratelimiting.
+func setJSONValue(sfv reflect.Value, mapKey string, mapValue interface{}) error {
+	b, err := json.Marshal(mapValue)
+	if err != nil {
+		return err
+	}
+
+	v := reflect.New(sfv.Type())
+	if err := json.Unmarshal(b, v.Interface()); err != nil {
+		return fmt.Errorf("Provided mapValue for %s didn't match configStruct field type %v: %v", mapKey, sfv.Type(), err)
 	}
 
+	sfv.Set(v.Elem())
 	return nil
 }
 
@@ -358,9 +611,9 @@ type RateLimitingConfig struct {
 	Second        int    `json:"second,omitempty"`
 	Minute        int    `json:"minute,omitempty"`
 	Hour          int    `json:"hour,omitempty"`
-	Day           int    `json:"day, omitempty"`
+	Day           int    `json:"day,omitempty"`
 	Month         int    `json:"month,omitempty"`
-	Year          int    `json:"year, omitempty"`
+	Year          int    `json:"year,omitempty"`
 	LimitBy       string `json:"limit_by,omitempty"`
 	Policy        string `json:"policy,omitempty"`
 	FaultTolerant *bool  `json:"fault_tolerant,omitempty"`
@@ -392,3 +645,203 @@ type KeyAuthenticationConfig struct {
 	KeyNames        []string `json:"key_names,omitempty"`
 	HideCredentials *bool    `json:"hide_credentials,omitempty"`
 }
+
+type BasicAuthConfig struct {
+	HideCredentials *bool  `json:"hide_credentials,omitempty"`
+	Anonymous       string `json:"anonymous,omitempty"`
+}
+
+type HMACAuthConfig struct {
+	HideCredentials     *bool    `json:"hide_credentials,omitempty"`
+	ClockSkew           int      `json:"clock_skew,omitempty"`
+	Anonymous           string   `json:"anonymous,omitempty"`
+	ValidateRequestBody *bool    `json:"validate_request_body,omitempty"`
+	EnforceHeaders      []string `json:"enforce_headers,omitempty"`
+	Algorithms          []string `json:"algorithms,omitempty"`
+}
+
+type OAuth2Config struct {
+	Scopes                        []string `json:"scopes,omitempty"`
+	MandatoryScope                *bool    `json:"mandatory_scope,omitempty"`
+	ProvisionKey                  string   `json:"provision_key,omitempty"`
+	TokenExpiration               int      `json:"token_expiration,omitempty"`
+	RefreshTokenTTL               int      `json:"refresh_token_ttl,omitempty"`
+	EnableAuthorizationCode       *bool    `json:"enable_authorization_code,omitempty"`
+	EnableImplicitGrant           *bool    `json:"enable_implicit_grant,omitempty"`
+	EnableClientCredentials       *bool    `json:"enable_client_credentials,omitempty"`
+	EnablePasswordGrant           *bool    `json:"enable_password_grant,omitempty"`
+	HideCredentials               *bool    `json:"hide_credentials,omitempty"`
+	AcceptHttpIfAlreadyTerminated *bool    `json:"accept_http_if_already_terminated,omitempty"`
+	GlobalCredentials             *bool    `json:"global_credentials,omitempty"`
+	Anonymous                     string   `json:"anonymous,omitempty"`
+}
+
+type CorsConfig struct {
+	Origins           []string `json:"origins,omitempty"`
+	Methods           []string `json:"methods,omitempty"`
+	Headers           []string `json:"headers,omitempty"`
+	ExposedHeaders    []string `json:"exposed_headers,omitempty"`
+	Credentials       *bool    `json:"credentials,omitempty"`
+	MaxAge            int      `json:"max_age,omitempty"`
+	PreflightContinue *bool    `json:"preflight_continue,omitempty"`
+}
+
+type IPRestrictionConfig struct {
+	Whitelist []string `json:"whitelist,omitempty"`
+	Blacklist []string `json:"blacklist,omitempty"`
+}
+
+type RequestTransformerConfig struct {
+	HttpMethod string                    `json:"http_method,omitempty"`
+	Remove     *RequestTransformerFields `json:"remove,omitempty"`
+	Rename     *RequestTransformerFields `json:"rename,omitempty"`
+	Replace    *RequestTransformerFields `json:"replace,omitempty"`
+	Add        *RequestTransformerFields `json:"add,omitempty"`
+	Append     *RequestTransformerFields `json:"append,omitempty"`
+}
+
+// RequestTransformerFields lists the parts of a request one of the
+// request-transformer operations applies to, as "name" for remove and
+// "name:value" otherwise.
+type RequestTransformerFields struct {
+	Headers     []string `json:"headers,omitempty"`
+	Querystring []string `json:"querystring,omitempty"`
+	Body        []string `json:"body,omitempty"`
+}
+
+type ResponseTransformerConfig struct {
+	Remove  *ResponseTransformerFields `json:"remove,omitempty"`
+	Replace *ResponseTransformerFields `json:"replace,omitempty"`
+	Add     *ResponseTransformerFields `json:"add,omitempty"`
+	Append  *ResponseTransformerFields `json:"append,omitempty"`
+}
+
+// ResponseTransformerFields lists the parts of a response one of the
+// response-transformer operations applies to, as "name" for remove and
+// "name:value" otherwise.
+type ResponseTransformerFields struct {
+	Headers []string `json:"headers,omitempty"`
+	JSON    []string `json:"json,omitempty"`
+}
+
+type ResponseRateLimitingConfig struct {
+	Limits                map[string]*ResponseRateLimits `json:"limits,omitempty"`
+	HeaderName            string                         `json:"header_name,omitempty"`
+	LimitBy               string                         `json:"limit_by,omitempty"`
+	Policy                string                         `json:"policy,omitempty"`
+	FaultTolerant         *bool                          `json:"fault_tolerant,omitempty"`
+	BlockOnFirstViolation *bool                          `json:"block_on_first_violation,omitempty"`
+	HideClientHeaders     *bool                          `json:"hide_client_headers,omitempty"`
+	RedisHost             string                         `json:"redis_host,omitempty"`
+	RedisPort             int                            `json:"redis_port,omitempty"`
+	RedisPassword         string                         `json:"redis_password,omitempty"`
+	RedisTimeout          int                            `json:"redis_timeout,omitempty"`
+	RedisDatabase         int                            `json:"redis_database,omitempty"`
+}
+
+// ResponseRateLimits holds the limits of a single response-ratelimiting
+// quota, i.e. the "sms" in a X-Kong-Limit: sms=1 upstream header.
+type ResponseRateLimits struct {
+	Second int `json:"second,omitempty"`
+	Minute int `json:"minute,omitempty"`
+	Hour   int `json:"hour,omitempty"`
+	Day    int `json:"day,omitempty"`
+	Month  int `json:"month,omitempty"`
+	Year   int `json:"year,omitempty"`
+}
+
+type BotDetectionConfig struct {
+	Whitelist []string `json:"whitelist,omitempty"`
+	Blacklist []string `json:"blacklist,omitempty"`
+}
+
+type TcpLogConfig struct {
+	Host      string `json:"host,omitempty"`
+	Port      int    `json:"port,omitempty"`
+	Timeout   int    `json:"timeout,omitempty"`
+	KeepAlive int    `json:"keepalive,omitempty"`
+}
+
+type UdpLogConfig struct {
+	Host    string `json:"host,omitempty"`
+	Port    int    `json:"port,omitempty"`
+	Timeout int    `json:"timeout,omitempty"`
+}
+
+type SyslogConfig struct {
+	LogLevel             string `json:"log_level,omitempty"`
+	SuccessfulSeverity   string `json:"successful_severity,omitempty"`
+	ClientErrorsSeverity string `json:"client_errors_severity,omitempty"`
+	ServerErrorsSeverity string `json:"server_errors_severity,omitempty"`
+}
+
+type StatsdConfig struct {
+	Host    string          `json:"host,omitempty"`
+	Port    int             `json:"port,omitempty"`
+	Prefix  string          `json:"prefix,omitempty"`
+	Metrics []*StatsdMetric `json:"metrics,omitempty"`
+}
+
+// StatsdMetric configures one of the metrics the statsd and datadog
+// plugins log, i.e. {Name: "request_count", StatType: "counter", SampleRate: 1}.
+type StatsdMetric struct {
+	Name               string   `json:"name,omitempty"`
+	StatType           string   `json:"stat_type,omitempty"`
+	SampleRate         float64  `json:"sample_rate,omitempty"`
+	ConsumerIdentifier string   `json:"consumer_identifier,omitempty"`
+	Tags               []string `json:"tags,omitempty"`
+}
+
+type DatadogConfig struct {
+	Host    string          `json:"host,omitempty"`
+	Port    int             `json:"port,omitempty"`
+	Prefix  string          `json:"prefix,omitempty"`
+	Metrics []*StatsdMetric `json:"metrics,omitempty"`
+}
+
+type LogglyConfig struct {
+	Host                 string   `json:"host,omitempty"`
+	Port                 int      `json:"port,omitempty"`
+	Key                  string   `json:"key,omitempty"`
+	Tags                 []string `json:"tags,omitempty"`
+	LogLevel             string   `json:"log_level,omitempty"`
+	SuccessfulSeverity   string   `json:"successful_severity,omitempty"`
+	ClientErrorsSeverity string   `json:"client_errors_severity,omitempty"`
+	ServerErrorsSeverity string   `json:"server_errors_severity,omitempty"`
+	Timeout              int      `json:"timeout,omitempty"`
+}
+
+type GalileoConfig struct {
+	ServiceToken      string `json:"service_token,omitempty"`
+	Environment       string `json:"environment,omitempty"`
+	LogBodies         *bool  `json:"log_bodies,omitempty"`
+	RetryCount        int    `json:"retry_count,omitempty"`
+	ConnectionTimeout int    `json:"connection_timeout,omitempty"`
+	FlushTimeout      int    `json:"flush_timeout,omitempty"`
+	QueueSize         int    `json:"queue_size,omitempty"`
+	Host              string `json:"host,omitempty"`
+	Port              int    `json:"port,omitempty"`
+	Https             *bool  `json:"https,omitempty"`
+}
+
+type LdapAuthConfig struct {
+	LdapHost        string `json:"ldap_host,omitempty"`
+	LdapPort        int    `json:"ldap_port,omitempty"`
+	StartTLS        *bool  `json:"start_tls,omitempty"`
+	VerifyLdapHost  *bool  `json:"verify_ldap_host,omitempty"`
+	BaseDN          string `json:"base_dn,omitempty"`
+	Attribute       string `json:"attribute,omitempty"`
+	CacheTTL        int    `json:"cache_ttl,omitempty"`
+	HideCredentials *bool  `json:"hide_credentials,omitempty"`
+	Timeout         int    `json:"timeout,omitempty"`
+	KeepAlive       int    `json:"keepalive,omitempty"`
+	Anonymous       string `json:"anonymous,omitempty"`
+	HeaderType      string `json:"header_type,omitempty"`
+}
+
+type RequestTerminationConfig struct {
+	StatusCode  int    `json:"status_code,omitempty"`
+	Message     string `json:"message,omitempty"`
+	Body        string `json:"body,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+}
@@ -203,6 +203,73 @@ func TestPluginsService_Post_badStatusCode(t *testing.T) {
 	}
 }
 
+func TestPluginsService_Create(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/plugins", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"p","name":"acl","enabled":true,"config":{"whitelist":["a"]}}`)
+	})
+
+	plugin, _, err := client.Plugins.Create(&Plugin{Name: "acl", Config: map[string]interface{}{"whitelist": []string{"a"}}})
+	if err != nil {
+		t.Errorf("Plugins.Create returned error: %v", err)
+	}
+
+	want := &Plugin{ID: "p", Name: "acl", Enabled: Bool(true), Config: map[string]interface{}{"whitelist": []interface{}{"a"}}}
+	if !reflect.DeepEqual(plugin, want) {
+		t.Errorf("Plugins.Create returned %+v, want %+v", plugin, want)
+	}
+}
+
+func TestPluginsService_Update(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/plugins/p", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"id":"p","enabled":false}`+"\n")
+		fmt.Fprint(w, `{"id":"p","name":"acl","enabled":false}`)
+	})
+
+	plugin, _, err := client.Plugins.Update(&Plugin{ID: "p", Enabled: Bool(false)})
+	if err != nil {
+		t.Errorf("Plugins.Update returned error: %v", err)
+	}
+
+	want := &Plugin{ID: "p", Name: "acl", Enabled: Bool(false)}
+	if !reflect.DeepEqual(plugin, want) {
+		t.Errorf("Plugins.Update returned %+v, want %+v", plugin, want)
+	}
+
+	if _, _, err := client.Plugins.Update(&Plugin{}); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestPluginsService_UpdateForApi(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/apis/a/plugins/p", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"id":"p","enabled":false}`+"\n")
+		fmt.Fprint(w, `{"id":"p","api_id":"a","name":"acl","enabled":false}`)
+	})
+
+	plugin, _, err := client.Plugins.UpdateForApi("a", &Plugin{ID: "p", Enabled: Bool(false)})
+	if err != nil {
+		t.Errorf("Plugins.UpdateForApi returned error: %v", err)
+	}
+
+	want := &Plugin{ID: "p", ApiID: "a", Name: "acl", Enabled: Bool(false)}
+	if !reflect.DeepEqual(plugin, want) {
+		t.Errorf("Plugins.UpdateForApi returned %+v, want %+v", plugin, want)
+	}
+}
+
 func TestPluginsService_GetAll(t *testing.T) {
 	stubSetup()
 	defer stubTeardown()
@@ -348,6 +415,148 @@ func TestPluginsService_FromMap(t *testing.T) {
 	}
 }
 
+func TestPluginsService_ToMap_pointer(t *testing.T) {
+	got := ToMap(&HMACAuthConfig{HideCredentials: Bool(false), ClockSkew: 300})
+
+	want := map[string]interface{}{"hide_credentials": false, "clock_skew": 300}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToMap returned %+v, want %+v", got, want)
+	}
+}
+
+func TestPluginsService_FromMap_bool(t *testing.T) {
+	got := &HMACAuthConfig{}
+	j := []byte(`{"hide_credentials": false, "clock_skew": 300, "validate_request_body": true, "algorithms": ["hmac-sha256"]}`)
+	m := make(map[string]interface{})
+	json.Unmarshal(j, &m)
+
+	err := FromMap(got, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &HMACAuthConfig{
+		HideCredentials:     Bool(false),
+		ClockSkew:           300,
+		ValidateRequestBody: Bool(true),
+		Algorithms:          []string{"hmac-sha256"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Error convering map to struct. got %+v, want %+v", got, want)
+	}
+
+	// And back again
+	roundTrip := &HMACAuthConfig{}
+	if err := FromMap(roundTrip, ToMap(want)); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(roundTrip, want) {
+		t.Errorf("FromMap(ToMap()) returned %+v, want %+v", roundTrip, want)
+	}
+
+	if err := SetJSONField(got, "hide_credentials", "yes"); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestRateLimitingConfig_omitEmpty(t *testing.T) {
+	b, err := json.Marshal(&RateLimitingConfig{Minute: 5})
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	if want := `{"minute":5}`; string(b) != want {
+		t.Errorf("json.Marshal returned %s, want %s", b, want)
+	}
+}
+
+func TestPluginConfigs_roundTrip(t *testing.T) {
+	configs := []interface{}{
+		&BasicAuthConfig{HideCredentials: Bool(true), Anonymous: "a"},
+		&HMACAuthConfig{ClockSkew: 300, ValidateRequestBody: Bool(false), EnforceHeaders: []string{"date"}},
+		&OAuth2Config{Scopes: []string{"email"}, MandatoryScope: Bool(true), TokenExpiration: 7200, EnableClientCredentials: Bool(true)},
+		&CorsConfig{Origins: []string{"*"}, Methods: []string{"GET"}, Credentials: Bool(false), MaxAge: 3600},
+		&IPRestrictionConfig{Whitelist: []string{"10.0.0.0/8"}},
+		&RequestTransformerConfig{
+			HttpMethod: "POST",
+			Remove:     &RequestTransformerFields{Headers: []string{"x-internal"}},
+			Add:        &RequestTransformerFields{Querystring: []string{"v:1"}, Body: []string{"a:b"}},
+		},
+		&ResponseTransformerConfig{Append: &ResponseTransformerFields{JSON: []string{"k:v"}}},
+		&ResponseRateLimitingConfig{
+			Limits:        map[string]*ResponseRateLimits{"sms": {Minute: 10}, "video": {Day: 5}},
+			FaultTolerant: Bool(true),
+			RedisPort:     6379,
+		},
+		&BotDetectionConfig{Blacklist: []string{"curl"}},
+		&TcpLogConfig{Host: "logs", Port: 514, KeepAlive: 60000},
+		&UdpLogConfig{Host: "logs", Port: 514, Timeout: 1000},
+		&SyslogConfig{LogLevel: "info", ServerErrorsSeverity: "crit"},
+		&StatsdConfig{Host: "statsd", Port: 8125, Metrics: []*StatsdMetric{{Name: "latency", StatType: "timer", SampleRate: 0.5}}},
+		&DatadogConfig{Prefix: "kong", Metrics: []*StatsdMetric{{Name: "request_count", StatType: "counter", SampleRate: 1, Tags: []string{"app:kong"}}}},
+		&LogglyConfig{Key: "k", Tags: []string{"kong"}, Timeout: 10000},
+		&GalileoConfig{ServiceToken: "t", LogBodies: Bool(false), QueueSize: 1000, Https: Bool(true)},
+		&LdapAuthConfig{LdapHost: "ldap", LdapPort: 389, StartTLS: Bool(false), BaseDN: "dc=example,dc=com", Attribute: "cn"},
+		&RequestTerminationConfig{StatusCode: 503, Message: "down for maintenance"},
+	}
+
+	for _, config := range configs {
+		typ := reflect.TypeOf(config).Elem()
+
+		// Straight from ToMap, and through JSON as when read back from Kong
+		m := ToMap(config)
+		b, err := json.Marshal(m)
+		if err != nil {
+			t.Fatalf("%v: json.Marshal returned error: %v", typ, err)
+		}
+		decoded := make(map[string]interface{})
+		json.Unmarshal(b, &decoded)
+
+		for _, m := range []map[string]interface{}{m, decoded} {
+			got := reflect.New(typ).Interface()
+			if err := FromMap(got, m); err != nil {
+				t.Errorf("%v: FromMap returned error: %v", typ, err)
+				continue
+			}
+			if !reflect.DeepEqual(got, config) {
+				t.Errorf("%v: FromMap(ToMap()) returned %+v, want %+v", typ, got, config)
+			}
+		}
+	}
+}
+
+func TestPluginsService_FromMap_null(t *testing.T) {
+	got := &LdapAuthConfig{}
+	err := FromMap(got, map[string]interface{}{"anonymous": nil, "ldap_host": "ldap"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &LdapAuthConfig{LdapHost: "ldap"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromMap returned %+v, want %+v", got, want)
+	}
+}
+
+func TestPluginsService_FromMap_mismatch(t *testing.T) {
+	tests := []struct {
+		config interface{}
+		key    string
+		value  interface{}
+	}{
+		{&IPRestrictionConfig{}, "whitelist", "10.0.0.1"},
+		{&StatsdConfig{}, "metrics", "latency"},
+		{&StatsdConfig{}, "port", "8125"},
+		{&RequestTransformerConfig{}, "add", []interface{}{"a:b"}},
+	}
+
+	for _, tt := range tests {
+		if err := SetJSONField(tt.config, tt.key, tt.value); err == nil {
+			t.Errorf("SetJSONField(%T, %q, %v) returned no error", tt.config, tt.key, tt.value)
+		}
+	}
+}
+
 func TestPluginsService_SetJSONField(t *testing.T) {
 	type S struct {
 		F1 string   `json:"f_1,omitempty"`
@@ -375,3 +584,57 @@ func TestPluginsService_SetJSONField(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestPluginsService_Iterator(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/plugins", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprint(w, `{"data":[{"id":"1"}],"total":2,"offset":"o"}`)
+		case "o":
+			fmt.Fprint(w, `{"data":[{"id":"2"}],"total":2}`)
+		}
+	})
+
+	var got []string
+	it := client.Plugins.Iterator(nil)
+	for it.Next() {
+		got = append(got, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Plugins.Iterator returned error: %v", err)
+	}
+
+	want := []string{"1", "2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Plugins.Iterator returned %v, want %v", got, want)
+	}
+}
+
+func TestApisPluginsService_ListAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/apis/a/plugins", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprint(w, `{"data":[{"id":"1"}],"total":2,"offset":"o"}`)
+		case "o":
+			fmt.Fprint(w, `{"data":[{"id":"2"}],"total":2}`)
+		}
+	})
+
+	plugins, err := client.Apis.Plugins.ListAll("a", nil, 0)
+	if err != nil {
+		t.Errorf("Apis.Plugins.ListAll returned error: %v", err)
+	}
+
+	want := []*Plugin{{ID: "1"}, {ID: "2"}}
+	if !reflect.DeepEqual(plugins, want) {
+		t.Errorf("Apis.Plugins.ListAll returned %+v, want %+v", plugins, want)
+	}
+}
@@ -0,0 +1,215 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how Client.Do retries requests that fail with a
+// transient error, such as Kong restarting behind a load balancer.
+//
+// A request is retried when the transport fails to get a response
+// because the connection was refused, reset or timed out, or when Kong
+// answers with one of the RetryableStatusCodes, as reported by
+// IsRetryable. Only idempotent methods are retried unless
+// RetryNonIdempotent is set. Request bodies built by NewRequest are
+// replayed on every attempt.
+type RetryPolicy struct {
+	MaxAttempts          int           // Total number of attempts, including the first. Values below 2 disable retries.
+	MinBackoff           time.Duration // Base delay before the first retry, doubled on every following attempt.
+	MaxBackoff           time.Duration // Upper bound on the delay between two attempts.
+	RetryableStatusCodes []int         // Response status codes which should be retried.
+	RetryNonIdempotent   bool          // Also retry POST and PATCH requests.
+
+	// Backoff, when set, replaces the default exponential backoff with
+	// jitter. It is passed the number of the attempt which just failed,
+	// starting at 1, and the response Kong returned, which is nil when
+	// the transport failed.
+	Backoff func(attempt int, resp *http.Response) time.Duration
+}
+
+// DefaultRetryPolicy returns a RetryPolicy which makes up to four attempts
+// on 429 and 5xx responses as well as connection errors, waiting between
+// 100ms and 5s between attempts.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  100 * time.Millisecond,
+		MaxBackoff:  5 * time.Second,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// idempotent reports whether a request with the given method can safely
+// be sent more than once.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// allows reports whether req may be retried at all under the policy.
+func (p *RetryPolicy) allows(req *http.Request) bool {
+	if p == nil || p.MaxAttempts < 2 {
+		return false
+	}
+
+	if !p.RetryNonIdempotent && !idempotent(req.Method) {
+		return false
+	}
+
+	// A body which cannot be rewound cannot be replayed
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// shouldRetry reports whether the outcome of an attempt is transient.
+func (p *RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return req.Context().Err() == nil && p.IsRetryable(err)
+	}
+
+	for _, c := range p.RetryableStatusCodes {
+		if resp.StatusCode == c {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRetryable reports whether err, or any error it wraps, is transient so
+// the request may succeed if sent again: a response with one of the
+// RetryableStatusCodes, a refused or reset connection, or a network
+// timeout. Cancellation and deadlines of the caller's context are not.
+func (p *RetryPolicy) IsRetryable(err error) bool {
+	if p == nil || err == nil {
+		return false
+	}
+
+	var e *ErrorResponse
+	if errors.As(err, &e) {
+		for _, c := range p.RetryableStatusCodes {
+			if e.statusCode() == c {
+				return true
+			}
+		}
+		return false
+	}
+
+	// context.DeadlineExceeded is a net.Error too
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// retryAfter returns the delay, in seconds, asked for by the Retry-After
+// header of resp, which may be nil.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	s, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || s < 0 {
+		return 0, false
+	}
+	return time.Duration(s) * time.Second, true
+}
+
+// backoff returns how long to wait after the given failed attempt.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if p.Backoff != nil {
+		return p.Backoff(attempt, resp)
+	}
+
+	// Respect Kong's, or the rate limiting plugin's, Retry-After header
+	if d, ok := retryAfter(resp); ok {
+		if p.MaxBackoff > 0 && d > p.MaxBackoff {
+			d = p.MaxBackoff
+		}
+		return d
+	}
+
+	d := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+
+	// Spread retries from many clients over [d/2, d]
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// do sends req through the underlying *http.Client, retrying transient
+// failures as described by c.RetryPolicy.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	p := c.RetryPolicy
+	if !p.allows(req) {
+		return c.client.Do(req)
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		r := req
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(ctx)
+			r.Body = body
+		}
+
+		resp, err := c.client.Do(r)
+		if attempt >= p.MaxAttempts || !p.shouldRetry(r, resp, err) {
+			return resp, err
+		}
+
+		wait := p.backoff(attempt, resp)
+		if resp != nil {
+			c.logf("%v %v: %d, retrying in %v", r.Method, r.URL, resp.StatusCode, wait)
+		} else {
+			c.logf("%v %v: %v, retrying in %v", r.Method, r.URL, err, wait)
+		}
+
+		// Discard the failed response so its connection can be reused
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package kong
+
+import (
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// failingServer returns a test server which answers the first failures
+// requests with status and every following request with 200. The
+// returned counter holds the number of requests received.
+func failingServer(failures int32, status int) (*httptest.Server, *int32) {
+	var calls int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if n := atomic.AddInt32(&calls, 1); n <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"id":"i"}`))
+	}))
+	return s, &calls
+}
+
+func noBackoff(attempt int, resp *http.Response) time.Duration {
+	return 0
+}
+
+func TestDo_retryStatusCode(t *testing.T) {
+	s, calls := failingServer(2, http.StatusServiceUnavailable)
+	defer s.Close()
+
+	c, _ := NewClient(nil, s.URL)
+	c.RetryPolicy = DefaultRetryPolicy()
+	c.RetryPolicy.Backoff = noBackoff
+
+	api, _, err := c.Apis.Get("i")
+	if err != nil {
+		t.Fatalf("Apis.Get returned error: %v", err)
+	}
+	if api.ID != "i" {
+		t.Errorf("Apis.Get returned %+v", api)
+	}
+	if got := atomic.LoadInt32(calls); got != 3 {
+		t.Errorf("Server received %d requests, want 3", got)
+	}
+}
+
+func TestDo_retryExhausted(t *testing.T) {
+	s, calls := failingServer(10, http.StatusBadGateway)
+	defer s.Close()
+
+	c, _ := NewClient(nil, s.URL)
+	c.RetryPolicy = DefaultRetryPolicy()
+	c.RetryPolicy.Backoff = noBackoff
+
+	_, resp, err := c.Apis.Get("i")
+	if err == nil {
+		t.Fatal("Expected error to be returned")
+	}
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("Response status is %d, want %d", resp.StatusCode, http.StatusBadGateway)
+	}
+	if got := atomic.LoadInt32(calls); got != 4 {
+		t.Errorf("Server received %d requests, want 4", got)
+	}
+}
+
+func TestDo_retryNotRetryableStatus(t *testing.T) {
+	s, calls := failingServer(1, http.StatusBadRequest)
+	defer s.Close()
+
+	c, _ := NewClient(nil, s.URL)
+	c.RetryPolicy = DefaultRetryPolicy()
+	c.RetryPolicy.Backoff = noBackoff
+
+	if _, _, err := c.Apis.Get("i"); err == nil {
+		t.Error("Expected error to be returned")
+	}
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("Server received %d requests, want 1", got)
+	}
+}
+
+func TestDo_retryNonIdempotent(t *testing.T) {
+	s, calls := failingServer(1, http.StatusServiceUnavailable)
+	defer s.Close()
+
+	c, _ := NewClient(nil, s.URL)
+	c.RetryPolicy = DefaultRetryPolicy()
+	c.RetryPolicy.Backoff = noBackoff
+
+	if _, err := c.Consumers.Post(&Consumer{Username: "u"}); err == nil {
+		t.Error("Expected error to be returned")
+	}
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("Server received %d requests, want 1", got)
+	}
+}
+
+func TestDo_retryReplaysBody(t *testing.T) {
+	var calls int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testBody(t, r, `{"username":"u"}`+"\n")
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer s.Close()
+
+	c, _ := NewClient(nil, s.URL)
+	c.RetryPolicy = DefaultRetryPolicy()
+	c.RetryPolicy.Backoff = noBackoff
+	c.RetryPolicy.RetryNonIdempotent = true
+
+	if _, err := c.Consumers.Post(&Consumer{Username: "u"}); err != nil {
+		t.Errorf("Consumers.Post returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("Server received %d requests, want 2", got)
+	}
+}
+
+func TestDo_retryConnectionRefused(t *testing.T) {
+	s := httptest.NewServer(http.NotFoundHandler())
+	s.Close()
+
+	var attempts int
+	c, _ := NewClient(nil, s.URL)
+	c.RetryPolicy = DefaultRetryPolicy()
+	c.RetryPolicy.Backoff = func(attempt int, resp *http.Response) time.Duration {
+		attempts = attempt
+		if resp != nil {
+			t.Errorf("Backoff received response %+v, want nil", resp)
+		}
+		return 0
+	}
+
+	if _, _, err := c.Apis.Get("i"); err == nil {
+		t.Error("Expected error to be returned")
+	}
+	if attempts != 3 {
+		t.Errorf("Backoff last called for attempt %d, want 3", attempts)
+	}
+}
+
+func TestDo_retryPermanentTransportError(t *testing.T) {
+	// The server logs the handshakes rejected by the client
+	s := httptest.NewUnstartedServer(http.NotFoundHandler())
+	s.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	s.StartTLS()
+	defer s.Close()
+
+	tests := map[string]struct {
+		url  string
+		rt   http.RoundTripper
+		want interface{}
+	}{
+		"dns": {
+			url: "http://kong:8001/",
+			rt: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				return nil, &net.DNSError{Err: "no such host", Name: "kong", IsNotFound: true}
+			}),
+			want: new(*net.DNSError),
+		},
+		"x509": {
+			url:  s.URL + "/",
+			rt:   http.DefaultTransport,
+			want: new(x509.UnknownAuthorityError),
+		},
+	}
+
+	for name, tt := range tests {
+		var attempts int
+		rt := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			return tt.rt.RoundTrip(req)
+		})
+
+		c, _ := NewClient(&http.Client{Transport: rt}, tt.url)
+		c.RetryPolicy = DefaultRetryPolicy()
+		c.RetryPolicy.Backoff = noBackoff
+
+		_, _, err := c.Apis.Get("i")
+		if !errors.As(err, tt.want) {
+			t.Errorf("%v: Apis.Get returned %v, want a %T", name, err, tt.want)
+		}
+		if attempts != 1 {
+			t.Errorf("%v: Transport received %d requests, want 1", name, attempts)
+		}
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	cases := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	}
+
+	for _, c := range cases {
+		if d := p.backoff(c.attempt, nil); d < c.min || d > c.max {
+			t.Errorf("backoff(%d) returned %v, want between %v and %v", c.attempt, d, c.min, c.max)
+		}
+	}
+}
+
+func TestRetryPolicy_backoffRetryAfter(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: 5 * time.Second}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+	if d := p.backoff(1, resp); d != 2*time.Second {
+		t.Errorf("backoff returned %v, want %v", d, 2*time.Second)
+	}
+
+	resp.Header.Set("Retry-After", "60")
+	if d := p.backoff(1, resp); d != 5*time.Second {
+		t.Errorf("backoff returned %v, want %v", d, 5*time.Second)
+	}
+}
+
+func TestRetryPolicy_allows(t *testing.T) {
+	p := DefaultRetryPolicy()
+
+	get, _ := http.NewRequest("GET", "/", nil)
+	if !p.allows(get) {
+		t.Error("Expected GET requests to be retried")
+	}
+
+	post, _ := http.NewRequest("POST", "/", nil)
+	if p.allows(post) {
+		t.Error("Expected POST requests not to be retried")
+	}
+
+	del, _ := http.NewRequest("DELETE", "/", ioutil.NopCloser(nil))
+	if p.allows(del) {
+		t.Error("Expected requests with bodies that cannot be replayed not to be retried")
+	}
+
+	var nilPolicy *RetryPolicy
+	if nilPolicy.allows(get) {
+		t.Error("Expected a nil policy not to retry")
+	}
+}
+
+func TestRetryPolicy_IsRetryable(t *testing.T) {
+	p := &RetryPolicy{RetryableStatusCodes: []int{http.StatusBadGateway}}
+
+	if !p.IsRetryable(&ServerError{Response: &http.Response{StatusCode: http.StatusBadGateway}}) {
+		t.Error("IsRetryable of a 502 = false, want true")
+	}
+	// Retried by DefaultRetryPolicy but not by p
+	if p.IsRetryable(&ServerError{Response: &http.Response{StatusCode: http.StatusServiceUnavailable}}) {
+		t.Error("IsRetryable of a 503 = true, want false")
+	}
+
+	var nilPolicy *RetryPolicy
+	if nilPolicy.IsRetryable(&ServerError{Response: &http.Response{StatusCode: http.StatusBadGateway}}) {
+		t.Error("IsRetryable of a nil policy = true, want false")
+	}
+}
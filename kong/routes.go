@@ -0,0 +1,245 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// RoutesService handles communication with Kong's '/routes' resource.
+type RoutesService struct {
+	*service
+}
+
+// Routes represents the object returned from Kong when querying for
+// multiple route objects.
+//
+// In cases where the number of objects returned exceeds the maximum,
+// Next holds the URI for the next set of results.
+// i.e. "http://localhost:8001/routes?offset=WyJmYjU3MjA1Ni1mODY1LTQ3"
+type Routes struct {
+	Data   []*Route `json:"data,omitempty"`
+	Total  int      `json:"total,omitempty"`
+	Next   string   `json:"next,omitempty"`
+	Offset string   `json:"offset,omitempty"`
+}
+
+// Route represents a single Kong route object, the set of matching rules
+// under which requests are forwarded to a service.
+//
+// Service only needs its ID set when creating or updating a route.
+// i.e. &Route{Paths: []string{"/mt"}, Service: &Service{ID: id}}
+type Route struct {
+	ID            string      `json:"id,omitempty"`
+	CreatedAt     int64       `json:"created_at,omitempty"`
+	UpdatedAt     int64       `json:"updated_at,omitempty"`
+	Name          string      `json:"name,omitempty"`
+	Protocols     []string    `json:"protocols,omitempty"`
+	Methods       []string    `json:"methods,omitempty"`
+	Hosts         []string    `json:"hosts,omitempty"`
+	Paths         []string    `json:"paths,omitempty"`
+	StripPath     *bool       `json:"strip_path,omitempty"`
+	PreserveHost  *bool       `json:"preserve_host,omitempty"`
+	RegexPriority int         `json:"regex_priority,omitempty"`
+	SNIs          []string    `json:"snis,omitempty"`
+	Sources       []*CIDRPort `json:"sources,omitempty"`
+	Destinations  []*CIDRPort `json:"destinations,omitempty"`
+	Service       *Service    `json:"service,omitempty"`
+	Tags          []string    `json:"tags,omitempty"`
+}
+
+// CIDRPort represents an ip and/or port pair used by the Sources and
+// Destinations of routes matching stream (tcp, tls) traffic.
+type CIDRPort struct {
+	IP   string `json:"ip,omitempty"`
+	Port int    `json:"port,omitempty"`
+}
+
+// Get queries for a single Kong route object, by id or name.
+//
+// Equivalent to GET /routes/{id or name}
+func (s *RoutesService) Get(route string) (*Route, *http.Response, error) {
+	return s.GetWithContext(context.Background(), route)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *RoutesService) GetWithContext(ctx context.Context, route string) (*Route, *http.Response, error) {
+	u := fmt.Sprintf("routes/%v", route)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Route)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Post creates a new Kong route object and returns it as stored by Kong.
+// route.Service must reference an existing service.
+//
+// Equivalent to POST /routes
+func (s *RoutesService) Post(route *Route) (*Route, *http.Response, error) {
+	return s.PostWithContext(context.Background(), route)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *RoutesService) PostWithContext(ctx context.Context, route *Route) (*Route, *http.Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, "routes", route)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Route)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Patch updates an existing Kong route object and returns it as stored
+// by Kong. At least one of route.ID or route.Name must be specified.
+//
+// Equivalent to PATCH /routes/{id or name}
+func (s *RoutesService) Patch(route *Route) (*Route, *http.Response, error) {
+	return s.PatchWithContext(context.Background(), route)
+}
+
+// PatchWithContext is like Patch but uses ctx for the request.
+func (s *RoutesService) PatchWithContext(ctx context.Context, route *Route) (*Route, *http.Response, error) {
+	var u string
+	if route.ID != "" {
+		u = fmt.Sprintf("routes/%v", route.ID)
+	} else if route.Name != "" {
+		u = fmt.Sprintf("routes/%v", route.Name)
+	} else {
+		return nil, nil, errors.New("At least one of route.ID or route.Name must be specified")
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, u, route)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Route)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Delete deletes a single Kong route object, by id or name.
+//
+// Equivalent to DELETE /routes/{id or name}
+func (s *RoutesService) Delete(route string) (*http.Response, error) {
+	return s.DeleteWithContext(context.Background(), route)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *RoutesService) DeleteWithContext(ctx context.Context, route string) (*http.Response, error) {
+	u := fmt.Sprintf("routes/%v", route)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+
+	return resp, err
+}
+
+// RoutesGetAllOptions specifies optional filter parameters to the
+// RoutesService.GetAll and ServicesRoutesService.GetAll methods.
+//
+// Additional information about filtering options can be found in
+// the Kong documentation at:
+// https://getkong.org/docs/0.13.x/admin-api/#list-routes
+type RoutesGetAllOptions struct {
+	Tags   string `url:"tags,omitempty"`   // A filter on the list based on tags, i.e. "a,b" or "a/b". Requires Kong 1.1 or later.
+	Size   int    `url:"size,omitempty"`   // A limit on the number of objects to be returned.
+	Offset string `url:"offset,omitempty"` // A cursor used for pagination. offset is an object identifier that defines a place in the list.
+}
+
+// GetAll queries for all Kong route objects.
+// This query can be filtered by supplying the RoutesGetAllOptions struct.
+//
+// Equivalent to GET /routes?uri=params&from=opt
+func (s *RoutesService) GetAll(opt *RoutesGetAllOptions) (*Routes, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *RoutesService) GetAllWithContext(ctx context.Context, opt *RoutesGetAllOptions) (*Routes, *http.Response, error) {
+	u, err := addOptions("routes", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	routes := new(Routes)
+	resp, err := s.client.Do(req, routes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return routes, resp, err
+}
+
+// newRoutesIterator builds an Iterator on top of getAll, which is
+// shared between the '/routes' and '/services/{service}/routes' listings.
+func newRoutesIterator(opt *RoutesGetAllOptions, getAll func(*RoutesGetAllOptions) (*Routes, error)) *Iterator[Route] {
+	o := new(RoutesGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+
+	return newIterator(o.Offset, func(offset string) ([]*Route, string, error) {
+		o.Offset = offset
+		routes, err := getAll(o)
+		if err != nil {
+			return nil, "", err
+		}
+		return routes.Data, nextOffset(routes.Next, routes.Offset), nil
+	})
+}
+
+// Iterator returns an Iterator over all Kong route objects.
+// opt.Size sets the page size and opt.Offset the starting point.
+func (s *RoutesService) Iterator(opt *RoutesGetAllOptions) *Iterator[Route] {
+	return s.IteratorWithContext(context.Background(), opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *RoutesService) IteratorWithContext(ctx context.Context, opt *RoutesGetAllOptions) *Iterator[Route] {
+	return newRoutesIterator(opt, func(o *RoutesGetAllOptions) (*Routes, error) {
+		routes, _, err := s.GetAllWithContext(ctx, o)
+		return routes, err
+	})
+}
+
+// ListAll follows Kong's pagination cursor and returns every route object
+// matching opt. If max is greater than zero no more than max objects are
+// returned.
+func (s *RoutesService) ListAll(opt *RoutesGetAllOptions, max int) ([]*Route, error) {
+	return s.ListAllWithContext(context.Background(), opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *RoutesService) ListAllWithContext(ctx context.Context, opt *RoutesGetAllOptions, max int) ([]*Route, error) {
+	return s.IteratorWithContext(ctx, opt).all(max)
+}
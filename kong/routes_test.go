@@ -0,0 +1,194 @@
+package kong
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestRoute_marshal(t *testing.T) {
+	testJSONMarshal(t, &Route{}, "{}")
+
+	r := &Route{
+		ID:            "i",
+		Protocols:     []string{"tcp"},
+		StripPath:     Bool(false),
+		PreserveHost:  Bool(true),
+		RegexPriority: 1,
+		SNIs:          []string{"example.com"},
+		Sources:       []*CIDRPort{{IP: "10.0.0.0/8"}},
+		Destinations:  []*CIDRPort{{IP: "10.1.0.1", Port: 443}},
+		Service:       &Service{ID: "s"},
+	}
+	want := `{
+		"id": "i",
+		"protocols": ["tcp"],
+		"strip_path": false,
+		"preserve_host": true,
+		"regex_priority": 1,
+		"snis": ["example.com"],
+		"sources": [{"ip": "10.0.0.0/8"}],
+		"destinations": [{"ip": "10.1.0.1", "port": 443}],
+		"service": {"id": "s"}
+	}`
+	testJSONMarshal(t, r, want)
+}
+
+func TestRoutesService_Get(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/routes/r", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"r","hosts":["h"],"methods":["GET"]}`)
+	})
+
+	route, _, err := client.Routes.Get("r")
+	if err != nil {
+		t.Errorf("Routes.Get returned error: %v", err)
+	}
+
+	want := &Route{ID: "r", Hosts: []string{"h"}, Methods: []string{"GET"}}
+	if !reflect.DeepEqual(route, want) {
+		t.Errorf("Routes.Get returned %+v, want %+v", route, want)
+	}
+}
+
+func TestRoutesService_Get_badStatusCode(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/routes/r", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+		fmt.Fprint(w, `{"error":"e"}`)
+	})
+
+	_, _, err := client.Routes.Get("r")
+	if err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestRoutesService_Post(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	input := &Route{Paths: []string{"/p"}, Service: &Service{ID: "s"}}
+
+	mux.HandleFunc("/routes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		v := new(Route)
+		json.NewDecoder(r.Body).Decode(v)
+		if !reflect.DeepEqual(v, input) {
+			t.Errorf("Request body = %+v, want %+v", v, input)
+		}
+		w.WriteHeader(201)
+		fmt.Fprint(w, `{"id":"r","paths":["/p"],"service":{"id":"s"}}`)
+	})
+
+	route, _, err := client.Routes.Post(input)
+	if err != nil {
+		t.Errorf("Routes.Post returned error: %v", err)
+	}
+
+	want := &Route{ID: "r", Paths: []string{"/p"}, Service: &Service{ID: "s"}}
+	if !reflect.DeepEqual(route, want) {
+		t.Errorf("Routes.Post returned %+v, want %+v", route, want)
+	}
+}
+
+func TestRoutesService_Patch(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/routes/r", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"id":"r","regex_priority":2}`+"\n")
+		fmt.Fprint(w, `{"id":"r","regex_priority":2}`)
+	})
+
+	route, _, err := client.Routes.Patch(&Route{ID: "r", RegexPriority: 2})
+	if err != nil {
+		t.Errorf("Routes.Patch returned error: %v", err)
+	}
+
+	want := &Route{ID: "r", RegexPriority: 2}
+	if !reflect.DeepEqual(route, want) {
+		t.Errorf("Routes.Patch returned %+v, want %+v", route, want)
+	}
+}
+
+func TestRoutesService_Patch_missingIDOrName(t *testing.T) {
+	_, _, err := client.Routes.Patch(&Route{})
+	if err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestRoutesService_Delete(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/routes/r", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(204)
+	})
+
+	_, err := client.Routes.Delete("r")
+	if err != nil {
+		t.Errorf("Routes.Delete returned error: %v", err)
+	}
+}
+
+func TestRoutesService_Delete_invalidRoute(t *testing.T) {
+	_, err := client.Routes.Delete("%")
+	testURLParseError(t, err)
+}
+
+func TestRoutesService_GetAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	v := &Routes{Total: 1, Data: []*Route{{ID: "r"}}}
+
+	mux.HandleFunc("/routes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"tags": "a,b"})
+		json.NewEncoder(w).Encode(v)
+	})
+
+	routes, _, err := client.Routes.GetAll(&RoutesGetAllOptions{Tags: "a,b"})
+	if err != nil {
+		t.Errorf("Routes.GetAll returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(routes, v) {
+		t.Errorf("Routes.GetAll returned %+v, want %+v", routes, v)
+	}
+}
+
+func TestRoutesService_ListAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/routes", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprint(w, `{"data":[{"id":"1"},{"id":"2"}],"offset":"o"}`)
+		case "o":
+			fmt.Fprint(w, `{"data":[{"id":"3"}]}`)
+		}
+	})
+
+	routes, err := client.Routes.ListAll(nil, 2)
+	if err != nil {
+		t.Errorf("Routes.ListAll returned error: %v", err)
+	}
+
+	want := []*Route{{ID: "1"}, {ID: "2"}}
+	if !reflect.DeepEqual(routes, want) {
+		t.Errorf("Routes.ListAll returned %+v, want %+v", routes, want)
+	}
+}
@@ -0,0 +1,381 @@
+package kong
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Schema describes the config of a Kong plugin, as returned by
+// GET /plugins/schema/{name}.
+//
+// Both layouts of Kong are parsed: the fields object of Kong 0.x, and the
+// fields array of Kong 1.x, which holds a single field per entry. The
+// schema of a whole 1.x plugin entity is reduced to its config record.
+type Schema struct {
+	Fields     map[string]*SchemaField `json:"fields,omitempty"`
+	NoConsumer bool                    `json:"no_consumer,omitempty"` // The plugin can't be applied to a single consumer.
+
+	// Flexible is set on nested records whose keys are arbitrary names,
+	// each holding a record of Fields, i.e. the limits of
+	// response-ratelimiting.
+	Flexible bool `json:"flexible,omitempty"`
+}
+
+// SchemaField describes a single field of a plugin config.
+type SchemaField struct {
+	// Type is one of string, boolean, number, timestamp, url, array or
+	// table in Kong 0.x, and one of string, boolean, integer, number,
+	// array, set, map or record in Kong 1.x.
+	Type      string        `json:"type,omitempty"`
+	Required  bool          `json:"required,omitempty"`
+	Default   interface{}   `json:"default,omitempty"`
+	Enum      []interface{} `json:"enum,omitempty"` // The one_of values in Kong 1.x.
+	Immutable bool          `json:"immutable,omitempty"`
+	Schema    *Schema       `json:"schema,omitempty"` // The nested record of a table or record field.
+
+	Elements *SchemaField `json:"elements,omitempty"` // The elements of an array or set field in Kong 1.x.
+	Keys     *SchemaField `json:"keys,omitempty"`     // The keys of a map field.
+	Values   *SchemaField `json:"values,omitempty"`   // The values of a map field.
+}
+
+// UnmarshalJSON parses a schema in either layout of Kong.
+func (s *Schema) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Fields     json.RawMessage `json:"fields"`
+		NoConsumer bool            `json:"no_consumer"`
+		Flexible   bool            `json:"flexible"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	fields, err := parseFields(raw.Fields)
+	if err != nil {
+		return err
+	}
+	*s = Schema{Fields: fields, NoConsumer: raw.NoConsumer, Flexible: raw.Flexible}
+
+	// A 1.x plugin entity holds its config next to fields such as
+	// consumer and protocols. Plugins which can't be applied to a single
+	// consumer constrain the consumer to null.
+	if config := fields["config"]; isArray(raw.Fields) && config != nil && config.Type == "record" && config.Schema != nil {
+		var entity []map[string]map[string]json.RawMessage
+		if err := json.Unmarshal(raw.Fields, &entity); err != nil {
+			return err
+		}
+		for _, f := range entity {
+			if eq, ok := f["consumer"]["eq"]; ok && string(eq) == "null" {
+				s.NoConsumer = true
+			}
+		}
+		s.Fields = config.Schema.Fields
+	}
+	return nil
+}
+
+// UnmarshalJSON parses a field in either layout of Kong, moving the
+// one_of values and nested fields of Kong 1.x to Enum and Schema.
+func (f *SchemaField) UnmarshalJSON(b []byte) error {
+	type field SchemaField
+	if err := json.Unmarshal(b, (*field)(f)); err != nil {
+		return err
+	}
+
+	var raw struct {
+		OneOf  []interface{}   `json:"one_of"`
+		Fields json.RawMessage `json:"fields"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	if f.Enum == nil {
+		f.Enum = raw.OneOf
+	}
+	if raw.Fields != nil && f.Schema == nil {
+		fields, err := parseFields(raw.Fields)
+		if err != nil {
+			return err
+		}
+		f.Schema = &Schema{Fields: fields}
+	}
+	return nil
+}
+
+// parseFields parses the fields of a schema, either an object of fields
+// keyed by name or an array of objects holding a single field each.
+func parseFields(b json.RawMessage) (map[string]*SchemaField, error) {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || string(b) == "null" {
+		return nil, nil
+	}
+
+	fields := make(map[string]*SchemaField)
+	switch {
+	case b[0] == '{':
+		if err := json.Unmarshal(b, &fields); err != nil {
+			return nil, err
+		}
+	case isArray(b):
+		var list []map[string]*SchemaField
+		if err := json.Unmarshal(b, &list); err != nil {
+			return nil, err
+		}
+		for _, f := range list {
+			if len(f) != 1 {
+				return nil, fmt.Errorf("unsupported schema format: expected a single field per entry of fields, got %d", len(f))
+			}
+			for name, field := range f {
+				fields[name] = field
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported schema format: fields is neither an object nor an array")
+	}
+	return fields, nil
+}
+
+// isArray reports whether b holds a JSON array.
+func isArray(b json.RawMessage) bool {
+	b = bytes.TrimSpace(b)
+	return len(b) > 0 && b[0] == '['
+}
+
+// FieldError is a problem with a single field of a plugin config.
+type FieldError struct {
+	Field   string // The path to the field, i.e. limits.video.minute
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Field, e.Message)
+}
+
+// ConfigError is returned when a plugin config does not match the schema
+// of the plugin, listing every offending field.
+type ConfigError struct {
+	Plugin string
+	Fields []*FieldError // Sorted by Field.
+}
+
+func (e *ConfigError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+	return fmt.Sprintf("invalid config for plugin %v: %v", e.Plugin, strings.Join(msgs, "; "))
+}
+
+// PluginsService.GetConfigSchema is like GetSchema but parses the schema
+// into a Schema.
+//
+// Equivalent to GET /plugins/schema/{name}
+func (s *PluginsService) GetConfigSchema(name string) (*Schema, *http.Response, error) {
+	return s.GetConfigSchemaWithContext(context.Background(), name)
+}
+
+// GetConfigSchemaWithContext is like GetConfigSchema but uses ctx for the
+// request.
+func (s *PluginsService) GetConfigSchemaWithContext(ctx context.Context, name string) (*Schema, *http.Response, error) {
+	u := fmt.Sprintf("plugins/schema/%v", name)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schema := new(Schema)
+	resp, err := s.client.Do(req, schema)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return schema, resp, err
+}
+
+// PluginsService.ValidateConfig fetches the schema of the plugin name and
+// checks config against it locally, returning a *ConfigError listing the
+// offending fields if it does not match.
+//
+// Callers validating many configs can fetch the schema once with
+// GetConfigSchema and use Schema.Validate instead.
+func (s *PluginsService) ValidateConfig(name string, config map[string]interface{}) (*http.Response, error) {
+	return s.ValidateConfigWithContext(context.Background(), name, config)
+}
+
+// ValidateConfigWithContext is like ValidateConfig but uses ctx for the
+// request.
+func (s *PluginsService) ValidateConfigWithContext(ctx context.Context, name string, config map[string]interface{}) (*http.Response, error) {
+	schema, resp, err := s.GetConfigSchemaWithContext(ctx, name)
+	if err != nil {
+		return resp, err
+	}
+
+	if err := schema.Validate(config); err != nil {
+		if cErr, ok := err.(*ConfigError); ok {
+			cErr.Plugin = name
+		}
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// Validate checks config, i.e. the Config of a Plugin, against s the way
+// Kong does on Post and Patch: unknown fields, missing required fields,
+// mismatched types and values outside an enum are reported as a
+// *ConfigError. Custom checks Kong runs in Lua can not be reproduced and
+// are left to Kong.
+//
+// config may hold any values encoding/json can marshal, such as the
+// output of ToMap.
+func (s *Schema) Validate(config map[string]interface{}) error {
+	// Normalize Go values to the ones Kong would see.
+	b, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	normalized := make(map[string]interface{})
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return err
+	}
+
+	var errs []*FieldError
+	s.validate("", normalized, &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+	return &ConfigError{Fields: errs}
+}
+
+// validate appends the problems with record, found at path, to errs.
+func (s *Schema) validate(path string, record map[string]interface{}, errs *[]*FieldError) {
+	for name, v := range record {
+		field, ok := s.Fields[name]
+		if !ok {
+			*errs = append(*errs, &FieldError{Field: path + name, Message: "unknown field"})
+			continue
+		}
+		if v != nil {
+			field.validate(path+name, v, errs)
+		}
+	}
+
+	for name, field := range s.Fields {
+		if v := record[name]; v == nil && field.Required && field.Default == nil {
+			*errs = append(*errs, &FieldError{Field: path + name, Message: "required field missing"})
+		}
+	}
+}
+
+// validate appends the problems with v, the value of f found at path, to
+// errs.
+func (f *SchemaField) validate(path string, v interface{}, errs *[]*FieldError) {
+	fail := func(format string, a ...interface{}) {
+		*errs = append(*errs, &FieldError{Field: path, Message: fmt.Sprintf(format, a...)})
+	}
+
+	switch f.Type {
+	case "string", "url":
+		s, ok := v.(string)
+		if !ok {
+			fail("expected a string")
+			return
+		}
+		f.checkEnum(s, fail)
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			fail("expected a boolean")
+		}
+	case "number", "timestamp":
+		if _, ok := v.(float64); !ok {
+			fail("expected a number")
+		}
+	case "integer":
+		if n, ok := v.(float64); !ok || n != math.Trunc(n) {
+			fail("expected an integer")
+		}
+	case "array", "set":
+		switch a := v.(type) {
+		case string:
+			// Kong 0.x splits comma separated strings into arrays
+			if f.Elements != nil {
+				fail("expected an array")
+				return
+			}
+			for _, e := range strings.Split(a, ",") {
+				f.checkEnum(e, fail)
+			}
+		case []interface{}:
+			for _, e := range a {
+				if f.Elements != nil {
+					f.Elements.validate(path, e, errs)
+					continue
+				}
+				f.checkEnum(e, fail)
+			}
+		default:
+			fail("expected an array")
+		}
+	case "map":
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			fail("expected a map")
+			return
+		}
+		for k, e := range m {
+			if f.Keys != nil {
+				f.Keys.validate(path+"."+k, k, errs)
+			}
+			if f.Values != nil && e != nil {
+				f.Values.validate(path+"."+k, e, errs)
+			}
+		}
+	case "table", "record":
+		t, ok := v.(map[string]interface{})
+		if !ok {
+			fail("expected a %v", f.Type)
+			return
+		}
+		if f.Schema == nil {
+			return
+		}
+		if !f.Schema.Flexible {
+			f.Schema.validate(path+".", t, errs)
+			return
+		}
+		for name, record := range t {
+			r, ok := record.(map[string]interface{})
+			if !ok {
+				*errs = append(*errs, &FieldError{Field: path + "." + name, Message: "expected a table"})
+				continue
+			}
+			f.Schema.validate(path+"."+name+".", r, errs)
+		}
+	}
+}
+
+// checkEnum reports v through fail unless f has no Enum or v is one of
+// its values.
+func (f *SchemaField) checkEnum(v interface{}, fail func(string, ...interface{})) {
+	if len(f.Enum) == 0 {
+		return
+	}
+
+	allowed := make([]string, len(f.Enum))
+	for i, e := range f.Enum {
+		if e == v {
+			return
+		}
+		allowed[i] = fmt.Sprintf("%q", fmt.Sprint(e))
+	}
+	fail("%q is not allowed. Allowed values are: %v", fmt.Sprint(v), strings.Join(allowed, ", "))
+}
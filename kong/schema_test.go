@@ -0,0 +1,307 @@
+package kong
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testSchema is the schema of response-ratelimiting, trimmed to the kinds
+// of fields it covers.
+const testSchema = `{
+	"no_consumer": false,
+	"fields": {
+		"limits": {"type": "table", "required": true, "schema": {"flexible": true, "fields": {
+			"minute": {"type": "number"},
+			"hour": {"type": "number"}
+		}}},
+		"header_name": {"type": "string", "default": "x-kong-limit"},
+		"policy": {"type": "string", "default": "cluster", "enum": ["local", "cluster", "redis"]},
+		"fault_tolerant": {"type": "boolean", "default": true},
+		"methods": {"type": "array", "enum": ["GET", "POST"]},
+		"redis": {"type": "table", "schema": {"fields": {
+			"host": {"type": "string", "required": true},
+			"port": {"type": "number", "default": 6379}
+		}}}
+	}
+}`
+
+// testSchema1x is the schema of response-ratelimiting as Kong 1.x returns
+// it from GET /schemas/plugins/{name}, trimmed to the kinds of fields it
+// covers.
+const testSchema1x = `{
+	"fields": [
+		{"consumer": {"type": "foreign", "reference": "consumers"}},
+		{"protocols": {"type": "set", "elements": {"type": "string", "one_of": ["http", "https"]}}},
+		{"config": {"type": "record", "required": true, "fields": [
+			{"limits": {"type": "map", "required": true, "keys": {"type": "string"}, "values": {
+				"type": "map", "required": true,
+				"keys": {"type": "string", "one_of": ["second", "minute", "hour"]},
+				"values": {"type": "number"}
+			}}},
+			{"header_name": {"type": "string", "default": "x-kong-limit"}},
+			{"policy": {"type": "string", "default": "cluster", "one_of": ["local", "cluster", "redis"]}},
+			{"fault_tolerant": {"type": "boolean", "required": true, "default": true}},
+			{"methods": {"type": "set", "elements": {"type": "string", "one_of": ["GET", "POST"]}}},
+			{"redis": {"type": "record", "fields": [
+				{"host": {"type": "string", "required": true}},
+				{"port": {"type": "integer", "default": 6379}}
+			]}}
+		]}}
+	]
+}`
+
+func TestPluginsService_GetConfigSchema(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/plugins/schema/key-auth", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"no_consumer":true,"fields":{"key_names":{"type":"array","required":true,"default":["apikey"]},"anonymous":{"type":"string","default":""}}}`)
+	})
+
+	schema, _, err := client.Plugins.GetConfigSchema("key-auth")
+	if err != nil {
+		t.Errorf("Plugins.GetConfigSchema returned error: %v", err)
+	}
+
+	want := &Schema{
+		NoConsumer: true,
+		Fields: map[string]*SchemaField{
+			"key_names": {Type: "array", Required: true, Default: []interface{}{"apikey"}},
+			"anonymous": {Type: "string", Default: ""},
+		},
+	}
+	if !reflect.DeepEqual(schema, want) {
+		t.Errorf("Plugins.GetConfigSchema returned %+v, want %+v", schema, want)
+	}
+}
+
+func TestSchema_Validate(t *testing.T) {
+	schema := new(Schema)
+	if err := json.Unmarshal([]byte(testSchema), schema); err != nil {
+		t.Fatalf("parsing schema: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+		want   []*FieldError
+	}{
+		{
+			name: "valid",
+			config: map[string]interface{}{
+				"limits":         map[string]interface{}{"video": map[string]interface{}{"minute": 10}},
+				"policy":         "redis",
+				"fault_tolerant": false,
+				"methods":        []string{"GET"},
+				"redis":          map[string]interface{}{"host": "127.0.0.1"},
+			},
+		},
+		{
+			name: "struct",
+			config: ToMap(&ResponseRateLimitingConfig{
+				Limits: map[string]*ResponseRateLimits{"video": {Minute: 10}},
+			}),
+		},
+		{
+			name: "comma separated array",
+			config: map[string]interface{}{
+				"limits":  map[string]interface{}{},
+				"methods": "GET,PUT",
+			},
+			want: []*FieldError{
+				{Field: "methods", Message: `"PUT" is not allowed. Allowed values are: "GET", "POST"`},
+			},
+		},
+		{
+			name: "invalid",
+			config: map[string]interface{}{
+				"limits":         map[string]interface{}{"video": map[string]interface{}{"minuet": 10, "hour": "1"}, "audio": 5},
+				"policy":         "memory",
+				"fault_tolerant": "yes",
+				"header":         "x",
+				"redis":          map[string]interface{}{"port": 1},
+			},
+			want: []*FieldError{
+				{Field: "fault_tolerant", Message: "expected a boolean"},
+				{Field: "header", Message: "unknown field"},
+				{Field: "limits.audio", Message: "expected a table"},
+				{Field: "limits.video.hour", Message: "expected a number"},
+				{Field: "limits.video.minuet", Message: "unknown field"},
+				{Field: "policy", Message: `"memory" is not allowed. Allowed values are: "local", "cluster", "redis"`},
+				{Field: "redis.host", Message: "required field missing"},
+			},
+		},
+		{
+			name:   "missing",
+			config: map[string]interface{}{"limits": nil},
+			want:   []*FieldError{{Field: "limits", Message: "required field missing"}},
+		},
+	}
+
+	for _, tt := range tests {
+		err := schema.Validate(tt.config)
+		if tt.want == nil {
+			if err != nil {
+				t.Errorf("%v: Schema.Validate returned error: %v", tt.name, err)
+			}
+			continue
+		}
+
+		cErr, ok := err.(*ConfigError)
+		if !ok {
+			t.Errorf("%v: Schema.Validate returned %v, want *ConfigError", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(cErr.Fields, tt.want) {
+			t.Errorf("%v: Schema.Validate returned %v, want %v", tt.name, cErr.Fields, tt.want)
+		}
+	}
+}
+
+func TestPluginsService_ValidateConfig(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/plugins/schema/response-ratelimiting", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, testSchema)
+	})
+
+	_, err := client.Plugins.ValidateConfig("response-ratelimiting", map[string]interface{}{"policy": "memory"})
+
+	want := `invalid config for plugin response-ratelimiting: limits: required field missing; ` +
+		`policy: "memory" is not allowed. Allowed values are: "local", "cluster", "redis"`
+	if err == nil || err.Error() != want {
+		t.Errorf("Plugins.ValidateConfig returned %v, want %v", err, want)
+	}
+
+	_, err = client.Plugins.ValidateConfig("response-ratelimiting", map[string]interface{}{"limits": map[string]interface{}{}})
+	if err != nil {
+		t.Errorf("Plugins.ValidateConfig returned error: %v", err)
+	}
+}
+
+func TestPluginsService_GetConfigSchema_kong1x(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/plugins/schema/key-auth", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"fields":[{"consumer":{"type":"foreign","reference":"consumers","eq":null}},`+
+			`{"config":{"type":"record","required":true,"fields":[`+
+			`{"key_names":{"type":"array","required":true,"default":["apikey"],"elements":{"type":"string"}}},`+
+			`{"anonymous":{"type":"string","default":""}}]}}]}`)
+	})
+
+	schema, _, err := client.Plugins.GetConfigSchema("key-auth")
+	if err != nil {
+		t.Errorf("Plugins.GetConfigSchema returned error: %v", err)
+	}
+
+	want := &Schema{
+		NoConsumer: true,
+		Fields: map[string]*SchemaField{
+			"key_names": {Type: "array", Required: true, Default: []interface{}{"apikey"}, Elements: &SchemaField{Type: "string"}},
+			"anonymous": {Type: "string", Default: ""},
+		},
+	}
+	if !reflect.DeepEqual(schema, want) {
+		t.Errorf("Plugins.GetConfigSchema returned %+v, want %+v", schema, want)
+	}
+}
+
+func TestSchema_unsupportedFormat(t *testing.T) {
+	for _, b := range []string{
+		`{"fields": "limits"}`,
+		`{"fields": [{"limits": {"type": "map"}, "policy": {"type": "string"}}]}`,
+	} {
+		err := json.Unmarshal([]byte(b), new(Schema))
+		if err == nil || !strings.Contains(err.Error(), "unsupported schema format") {
+			t.Errorf("Parsing %v returned %v, want an unsupported schema format error", b, err)
+		}
+	}
+}
+
+func TestSchema_Validate_kong1x(t *testing.T) {
+	schema := new(Schema)
+	if err := json.Unmarshal([]byte(testSchema1x), schema); err != nil {
+		t.Fatalf("parsing schema: %v", err)
+	}
+	if _, ok := schema.Fields["consumer"]; ok || schema.NoConsumer {
+		t.Errorf("Parsed schema %+v, want the config record of a plugin which accepts consumers", schema)
+	}
+
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+		want   []*FieldError
+	}{
+		{
+			name: "valid",
+			config: map[string]interface{}{
+				"limits":         map[string]interface{}{"video": map[string]interface{}{"minute": 10}},
+				"policy":         "redis",
+				"fault_tolerant": false,
+				"methods":        []string{"GET"},
+				"redis":          map[string]interface{}{"host": "127.0.0.1", "port": 6380},
+			},
+		},
+		{
+			name: "invalid",
+			config: map[string]interface{}{
+				"limits":  map[string]interface{}{"video": map[string]interface{}{"minuet": 10, "hour": "1"}, "audio": 5},
+				"policy":  "memory",
+				"methods": "GET,PUT",
+				"header":  "x",
+				"redis":   map[string]interface{}{"port": 1.5},
+			},
+			want: []*FieldError{
+				{Field: "header", Message: "unknown field"},
+				{Field: "limits.audio", Message: "expected a map"},
+				{Field: "limits.video.hour", Message: "expected a number"},
+				{Field: "limits.video.minuet", Message: `"minuet" is not allowed. Allowed values are: "second", "minute", "hour"`},
+				{Field: "methods", Message: "expected an array"},
+				{Field: "policy", Message: `"memory" is not allowed. Allowed values are: "local", "cluster", "redis"`},
+				{Field: "redis.host", Message: "required field missing"},
+				{Field: "redis.port", Message: "expected an integer"},
+			},
+		},
+		{
+			name:   "set elements",
+			config: map[string]interface{}{"limits": map[string]interface{}{}, "methods": []string{"GET", "PUT"}},
+			want:   []*FieldError{{Field: "methods", Message: `"PUT" is not allowed. Allowed values are: "GET", "POST"`}},
+		},
+		{
+			name:   "missing",
+			config: map[string]interface{}{"redis": "localhost"},
+			want: []*FieldError{
+				{Field: "limits", Message: "required field missing"},
+				{Field: "redis", Message: "expected a record"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		err := schema.Validate(tt.config)
+		if tt.want == nil {
+			if err != nil {
+				t.Errorf("%v: Schema.Validate returned error: %v", tt.name, err)
+			}
+			continue
+		}
+
+		cErr, ok := err.(*ConfigError)
+		if !ok {
+			t.Errorf("%v: Schema.Validate returned %v, want *ConfigError", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(cErr.Fields, tt.want) {
+			t.Errorf("%v: Schema.Validate returned %v, want %v", tt.name, cErr.Fields, tt.want)
+		}
+	}
+}
@@ -0,0 +1,430 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ServicesService handles communication with Kong's '/services' resource.
+//
+// Services and routes replace the '/apis' resource from Kong 0.13 onwards.
+type ServicesService struct {
+	*service
+	Routes  *ServicesRoutesService
+	Plugins *ServicesPluginsService
+}
+
+// Services represents the object returned from Kong when querying for
+// multiple service objects.
+//
+// In cases where the number of objects returned exceeds the maximum,
+// Next holds the URI for the next set of results.
+// i.e. "http://localhost:8001/services?offset=WyJmYjU3MjA1Ni1mODY1LTQ3"
+type Services struct {
+	Data   []*Service `json:"data,omitempty"`
+	Total  int        `json:"total,omitempty"`
+	Next   string     `json:"next,omitempty"`
+	Offset string     `json:"offset,omitempty"`
+}
+
+// Service represents a single Kong service object, an upstream API or
+// microservice that routes forward requests to.
+//
+// URL is a write-only shorthand which Kong splits into Protocol, Host,
+// Port and Path.
+type Service struct {
+	ID             string   `json:"id,omitempty"`
+	CreatedAt      int64    `json:"created_at,omitempty"`
+	UpdatedAt      int64    `json:"updated_at,omitempty"`
+	Name           string   `json:"name,omitempty"`
+	Protocol       string   `json:"protocol,omitempty"`
+	Host           string   `json:"host,omitempty"`
+	Port           int      `json:"port,omitempty"`
+	Path           string   `json:"path,omitempty"`
+	URL            string   `json:"url,omitempty"`
+	Retries        *int     `json:"retries,omitempty"`
+	ConnectTimeout int      `json:"connect_timeout,omitempty"`
+	WriteTimeout   int      `json:"write_timeout,omitempty"`
+	ReadTimeout    int      `json:"read_timeout,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+}
+
+// Get queries for a single Kong service object, by name or id.
+//
+// Equivalent to GET /services/{name or id}
+func (s *ServicesService) Get(service string) (*Service, *http.Response, error) {
+	return s.GetWithContext(context.Background(), service)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *ServicesService) GetWithContext(ctx context.Context, service string) (*Service, *http.Response, error) {
+	u := fmt.Sprintf("services/%v", service)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Service)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Post creates a new Kong service object and returns it as stored by Kong.
+//
+// Equivalent to POST /services
+func (s *ServicesService) Post(service *Service) (*Service, *http.Response, error) {
+	return s.PostWithContext(context.Background(), service)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *ServicesService) PostWithContext(ctx context.Context, service *Service) (*Service, *http.Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, "services", service)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Service)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Patch updates an existing Kong service object and returns it as stored
+// by Kong. At least one of service.ID or service.Name must be specified.
+//
+// Equivalent to PATCH /services/{id or name}
+func (s *ServicesService) Patch(service *Service) (*Service, *http.Response, error) {
+	return s.PatchWithContext(context.Background(), service)
+}
+
+// PatchWithContext is like Patch but uses ctx for the request.
+func (s *ServicesService) PatchWithContext(ctx context.Context, service *Service) (*Service, *http.Response, error) {
+	var u string
+	if service.ID != "" {
+		u = fmt.Sprintf("services/%v", service.ID)
+	} else if service.Name != "" {
+		u = fmt.Sprintf("services/%v", service.Name)
+	} else {
+		return nil, nil, errors.New("At least one of service.ID or service.Name must be specified")
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, u, service)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Service)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Delete deletes a single Kong service object, by name or id.
+// Kong refuses to delete a service which still has routes attached.
+//
+// Equivalent to DELETE /services/{name or id}
+func (s *ServicesService) Delete(service string) (*http.Response, error) {
+	return s.DeleteWithContext(context.Background(), service)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *ServicesService) DeleteWithContext(ctx context.Context, service string) (*http.Response, error) {
+	u := fmt.Sprintf("services/%v", service)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+
+	return resp, err
+}
+
+// ServicesGetAllOptions specifies optional filter parameters to the
+// ServicesService.GetAll method.
+//
+// Additional information about filtering options can be found in
+// the Kong documentation at:
+// https://getkong.org/docs/0.13.x/admin-api/#list-services
+type ServicesGetAllOptions struct {
+	Tags   string `url:"tags,omitempty"`   // A filter on the list based on tags, i.e. "a,b" or "a/b". Requires Kong 1.1 or later.
+	Size   int    `url:"size,omitempty"`   // A limit on the number of objects to be returned.
+	Offset string `url:"offset,omitempty"` // A cursor used for pagination. offset is an object identifier that defines a place in the list.
+}
+
+// GetAll queries for all Kong service objects.
+// This query can be filtered by supplying the ServicesGetAllOptions struct.
+//
+// Equivalent to GET /services?uri=params&from=opt
+func (s *ServicesService) GetAll(opt *ServicesGetAllOptions) (*Services, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *ServicesService) GetAllWithContext(ctx context.Context, opt *ServicesGetAllOptions) (*Services, *http.Response, error) {
+	u, err := addOptions("services", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	services := new(Services)
+	resp, err := s.client.Do(req, services)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return services, resp, err
+}
+
+// Iterator returns an Iterator over all Kong service objects.
+// opt.Size sets the page size and opt.Offset the starting point.
+func (s *ServicesService) Iterator(opt *ServicesGetAllOptions) *Iterator[Service] {
+	return s.IteratorWithContext(context.Background(), opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *ServicesService) IteratorWithContext(ctx context.Context, opt *ServicesGetAllOptions) *Iterator[Service] {
+	o := new(ServicesGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+
+	return newIterator(o.Offset, func(offset string) ([]*Service, string, error) {
+		o.Offset = offset
+		services, _, err := s.GetAllWithContext(ctx, o)
+		if err != nil {
+			return nil, "", err
+		}
+		return services.Data, nextOffset(services.Next, services.Offset), nil
+	})
+}
+
+// ListAll follows Kong's pagination cursor and returns every service
+// object matching opt. If max is greater than zero no more than max
+// objects are returned.
+func (s *ServicesService) ListAll(opt *ServicesGetAllOptions, max int) ([]*Service, error) {
+	return s.ListAllWithContext(context.Background(), opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *ServicesService) ListAllWithContext(ctx context.Context, opt *ServicesGetAllOptions, max int) ([]*Service, error) {
+	return s.IteratorWithContext(ctx, opt).all(max)
+}
+
+// ServicesRoutesService handles communication with Kong's
+// '/services/{name or id}/routes' resource.
+type ServicesRoutesService service
+
+// GetAll lists all routes attached to the specified service.
+//
+// Equivalent to GET /services/{name or id}/routes?uri=params&from=opt
+func (s *ServicesRoutesService) GetAll(service string, opt *RoutesGetAllOptions) (*Routes, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), service, opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *ServicesRoutesService) GetAllWithContext(ctx context.Context, service string, opt *RoutesGetAllOptions) (*Routes, *http.Response, error) {
+	u, err := addOptions(fmt.Sprintf("services/%v/routes", service), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	routes := new(Routes)
+	resp, err := s.client.Do(req, routes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return routes, resp, err
+}
+
+// Post creates a new Kong route object attached to the specified service
+// and returns it as stored by Kong.
+//
+// Equivalent to POST /services/{name or id}/routes
+func (s *ServicesRoutesService) Post(service string, route *Route) (*Route, *http.Response, error) {
+	return s.PostWithContext(context.Background(), service, route)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *ServicesRoutesService) PostWithContext(ctx context.Context, service string, route *Route) (*Route, *http.Response, error) {
+	u := fmt.Sprintf("services/%v/routes", service)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, u, route)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Route)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Iterator returns an Iterator over all routes attached to the
+// specified service.
+func (s *ServicesRoutesService) Iterator(service string, opt *RoutesGetAllOptions) *Iterator[Route] {
+	return s.IteratorWithContext(context.Background(), service, opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *ServicesRoutesService) IteratorWithContext(ctx context.Context, service string, opt *RoutesGetAllOptions) *Iterator[Route] {
+	return newRoutesIterator(opt, func(o *RoutesGetAllOptions) (*Routes, error) {
+		routes, _, err := s.GetAllWithContext(ctx, service, o)
+		return routes, err
+	})
+}
+
+// ListAll returns every route attached to the specified service,
+// following Kong's pagination cursor. If max is greater than zero no more
+// than max routes are returned.
+func (s *ServicesRoutesService) ListAll(service string, opt *RoutesGetAllOptions, max int) ([]*Route, error) {
+	return s.ListAllWithContext(context.Background(), service, opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *ServicesRoutesService) ListAllWithContext(ctx context.Context, service string, opt *RoutesGetAllOptions, max int) ([]*Route, error) {
+	return s.IteratorWithContext(ctx, service, opt).all(max)
+}
+
+// ServicesPluginsService handles communication with Kong's
+// '/services/{name or id}/plugins' resource.
+type ServicesPluginsService service
+
+// GetAll lists all plugins attached to the specified service.
+// This query can be filtered by supplying the PluginsGetAllOptions struct.
+//
+// Equivalent to GET /services/{name or id}/plugins?uri=params&from=opt
+func (s *ServicesPluginsService) GetAll(service string, opt *PluginsGetAllOptions) (*Plugins, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), service, opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *ServicesPluginsService) GetAllWithContext(ctx context.Context, service string, opt *PluginsGetAllOptions) (*Plugins, *http.Response, error) {
+	u, err := addOptions(fmt.Sprintf("services/%v/plugins", service), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plugins := new(Plugins)
+	resp, err := s.client.Do(req, plugins)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return plugins, resp, err
+}
+
+// Post creates a new Kong plugin object attached to the specified service
+// and returns it as stored by Kong.
+//
+// Equivalent to POST /services/{name or id}/plugins
+func (s *ServicesPluginsService) Post(service string, plugin *Plugin) (*Plugin, *http.Response, error) {
+	return s.PostWithContext(context.Background(), service, plugin)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *ServicesPluginsService) PostWithContext(ctx context.Context, service string, plugin *Plugin) (*Plugin, *http.Response, error) {
+	u := fmt.Sprintf("services/%v/plugins", service)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, u, plugin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Plugin)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Patch modifies the configuration of the specified plugin object attached
+// to the specified service. plugin.ID must be provided.
+//
+// Equivalent to PATCH /services/{name or id}/plugins/{pluginID}
+func (s *ServicesPluginsService) Patch(service string, plugin *Plugin) (*Plugin, *http.Response, error) {
+	return s.PatchWithContext(context.Background(), service, plugin)
+}
+
+// PatchWithContext is like Patch but uses ctx for the request.
+func (s *ServicesPluginsService) PatchWithContext(ctx context.Context, service string, plugin *Plugin) (*Plugin, *http.Response, error) {
+	if plugin.ID == "" {
+		return nil, nil, errors.New("plugin.ID must be specified")
+	}
+
+	u := fmt.Sprintf("services/%v/plugins/%v", service, plugin.ID)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, u, plugin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Plugin)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Iterator returns an Iterator over all plugins attached to the
+// specified service.
+func (s *ServicesPluginsService) Iterator(service string, opt *PluginsGetAllOptions) *Iterator[Plugin] {
+	return s.IteratorWithContext(context.Background(), service, opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *ServicesPluginsService) IteratorWithContext(ctx context.Context, service string, opt *PluginsGetAllOptions) *Iterator[Plugin] {
+	return newPluginsIterator(opt, func(o *PluginsGetAllOptions) (*Plugins, error) {
+		plugins, _, err := s.GetAllWithContext(ctx, service, o)
+		return plugins, err
+	})
+}
+
+// ListAll returns every plugin attached to the specified service,
+// following Kong's pagination cursor. If max is greater than zero no more
+// than max plugins are returned.
+func (s *ServicesPluginsService) ListAll(service string, opt *PluginsGetAllOptions, max int) ([]*Plugin, error) {
+	return s.ListAllWithContext(context.Background(), service, opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *ServicesPluginsService) ListAllWithContext(ctx context.Context, service string, opt *PluginsGetAllOptions, max int) ([]*Plugin, error) {
+	return s.IteratorWithContext(ctx, service, opt).all(max)
+}
@@ -0,0 +1,308 @@
+package kong
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestServicesService_Get(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/services/s", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"i","name":"s","protocol":"http","host":"h","port":80,"retries":0}`)
+	})
+
+	service, _, err := client.Services.Get("s")
+	if err != nil {
+		t.Errorf("Services.Get returned error: %v", err)
+	}
+
+	want := &Service{ID: "i", Name: "s", Protocol: "http", Host: "h", Port: 80, Retries: Int(0)}
+	if !reflect.DeepEqual(service, want) {
+		t.Errorf("Services.Get returned %+v, want %+v", service, want)
+	}
+}
+
+func TestServicesService_Get_invalidService(t *testing.T) {
+	_, _, err := client.Services.Get("%")
+	testURLParseError(t, err)
+}
+
+func TestServicesService_Get_badStatusCode(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/services/s", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		fmt.Fprint(w, `{"message":"Not found"}`)
+	})
+
+	_, _, err := client.Services.Get("s")
+	if _, ok := err.(*NotFoundError); !ok {
+		t.Errorf("Services.Get returned error %v, want *NotFoundError", err)
+	}
+}
+
+func TestServicesService_Post(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	input := &Service{Name: "s", URL: "http://h:80/p"}
+
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"name":"s","url":"http://h:80/p"}`+"\n")
+		w.WriteHeader(201)
+		fmt.Fprint(w, `{"id":"i","name":"s","protocol":"http","host":"h","port":80,"path":"/p"}`)
+	})
+
+	service, _, err := client.Services.Post(input)
+	if err != nil {
+		t.Errorf("Services.Post returned error: %v", err)
+	}
+
+	want := &Service{ID: "i", Name: "s", Protocol: "http", Host: "h", Port: 80, Path: "/p"}
+	if !reflect.DeepEqual(service, want) {
+		t.Errorf("Services.Post returned %+v, want %+v", service, want)
+	}
+}
+
+func TestServicesService_Post_badStatusCode(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"name":"already exists with value 's'"}`)
+	})
+
+	_, _, err := client.Services.Post(&Service{Name: "s"})
+	if _, ok := err.(*ConflictError); !ok {
+		t.Errorf("Services.Post returned error %v, want *ConflictError", err)
+	}
+}
+
+func TestServicesService_Patch_byID(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	input := &Service{ID: "i", Name: "s", Retries: Int(0)}
+
+	mux.HandleFunc("/services/i", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		v := new(Service)
+		json.NewDecoder(r.Body).Decode(v)
+		if !reflect.DeepEqual(v, input) {
+			t.Errorf("Request body = %+v, want %+v", v, input)
+		}
+		fmt.Fprint(w, `{"id":"i","name":"s","retries":0}`)
+	})
+
+	service, _, err := client.Services.Patch(input)
+	if err != nil {
+		t.Errorf("Services.Patch returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(service, input) {
+		t.Errorf("Services.Patch returned %+v, want %+v", service, input)
+	}
+}
+
+func TestServicesService_Patch_byName(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/services/s", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		fmt.Fprint(w, `{"id":"i","name":"s"}`)
+	})
+
+	_, _, err := client.Services.Patch(&Service{Name: "s"})
+	if err != nil {
+		t.Errorf("Services.Patch returned error: %v", err)
+	}
+}
+
+func TestServicesService_Patch_missingIDOrName(t *testing.T) {
+	_, _, err := client.Services.Patch(&Service{})
+	if err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestServicesService_Delete(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/services/s", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(204)
+	})
+
+	_, err := client.Services.Delete("s")
+	if err != nil {
+		t.Errorf("Services.Delete returned error: %v", err)
+	}
+}
+
+func TestServicesService_Delete_invalidService(t *testing.T) {
+	_, err := client.Services.Delete("%")
+	testURLParseError(t, err)
+}
+
+func TestServicesService_GetAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	v := &Services{Total: 1, Next: "n", Offset: "o2", Data: []*Service{{ID: "i"}}}
+
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"offset": "o", "size": "1"})
+		json.NewEncoder(w).Encode(v)
+	})
+
+	services, _, err := client.Services.GetAll(&ServicesGetAllOptions{Size: 1, Offset: "o"})
+	if err != nil {
+		t.Errorf("Services.GetAll returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(services, v) {
+		t.Errorf("Services.GetAll returned %+v, want %+v", services, v)
+	}
+}
+
+func TestServicesService_ListAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprint(w, `{"data":[{"id":"1"}],"next":"/services?offset=o","offset":"o"}`)
+		case "o":
+			fmt.Fprint(w, `{"data":[{"id":"2"}],"next":null}`)
+		}
+	})
+
+	services, err := client.Services.ListAll(nil, 0)
+	if err != nil {
+		t.Errorf("Services.ListAll returned error: %v", err)
+	}
+
+	want := []*Service{{ID: "1"}, {ID: "2"}}
+	if !reflect.DeepEqual(services, want) {
+		t.Errorf("Services.ListAll returned %+v, want %+v", services, want)
+	}
+}
+
+func TestServicesRoutesService_GetAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/services/s/routes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"size": "5"})
+		fmt.Fprint(w, `{"data":[{"id":"r","paths":["/p"],"service":{"id":"i"}}]}`)
+	})
+
+	routes, _, err := client.Services.Routes.GetAll("s", &RoutesGetAllOptions{Size: 5})
+	if err != nil {
+		t.Errorf("Services.Routes.GetAll returned error: %v", err)
+	}
+
+	want := &Routes{Data: []*Route{{ID: "r", Paths: []string{"/p"}, Service: &Service{ID: "i"}}}}
+	if !reflect.DeepEqual(routes, want) {
+		t.Errorf("Services.Routes.GetAll returned %+v, want %+v", routes, want)
+	}
+}
+
+func TestServicesRoutesService_Post(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/services/s/routes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"paths":["/p"],"strip_path":false}`+"\n")
+		fmt.Fprint(w, `{"id":"r","paths":["/p"],"strip_path":false,"service":{"id":"i"}}`)
+	})
+
+	route, _, err := client.Services.Routes.Post("s", &Route{Paths: []string{"/p"}, StripPath: Bool(false)})
+	if err != nil {
+		t.Errorf("Services.Routes.Post returned error: %v", err)
+	}
+
+	want := &Route{ID: "r", Paths: []string{"/p"}, StripPath: Bool(false), Service: &Service{ID: "i"}}
+	if !reflect.DeepEqual(route, want) {
+		t.Errorf("Services.Routes.Post returned %+v, want %+v", route, want)
+	}
+}
+
+func TestServicesPluginsService_Post(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/services/s/plugins", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"name":"acl"}`+"\n")
+		fmt.Fprint(w, `{"id":"p","name":"acl","service_id":"i"}`)
+	})
+
+	plugin, _, err := client.Services.Plugins.Post("s", &Plugin{Name: "acl"})
+	if err != nil {
+		t.Errorf("Services.Plugins.Post returned error: %v", err)
+	}
+
+	want := &Plugin{ID: "p", Name: "acl", ServiceID: "i"}
+	if !reflect.DeepEqual(plugin, want) {
+		t.Errorf("Services.Plugins.Post returned %+v, want %+v", plugin, want)
+	}
+}
+
+func TestServicesPluginsService_Patch(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/services/s/plugins/p", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		fmt.Fprint(w, `{"id":"p","name":"acl"}`)
+	})
+
+	_, _, err := client.Services.Plugins.Patch("s", &Plugin{ID: "p", Name: "acl"})
+	if err != nil {
+		t.Errorf("Services.Plugins.Patch returned error: %v", err)
+	}
+}
+
+func TestServicesPluginsService_Patch_missingID(t *testing.T) {
+	_, _, err := client.Services.Plugins.Patch("s", &Plugin{Name: "acl"})
+	if err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestServicesPluginsService_ListAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/services/s/plugins", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"name": "acl"})
+		fmt.Fprint(w, `{"data":[{"id":"p"}]}`)
+	})
+
+	plugins, err := client.Services.Plugins.ListAll("s", &PluginsGetAllOptions{Name: "acl"}, 0)
+	if err != nil {
+		t.Errorf("Services.Plugins.ListAll returned error: %v", err)
+	}
+
+	want := []*Plugin{{ID: "p"}}
+	if !reflect.DeepEqual(plugins, want) {
+		t.Errorf("Services.Plugins.ListAll returned %+v, want %+v", plugins, want)
+	}
+}
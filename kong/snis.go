@@ -0,0 +1,228 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// SNIsService handles communication with Kong's '/snis' resource.
+type SNIsService struct {
+	*service
+}
+
+// SNIs represents the object returned from Kong when querying for
+// multiple SNI objects.
+//
+// In cases where the number of objects returned exceeds the maximum,
+// Next holds the URI for the next set of results.
+// i.e. "http://localhost:8001/snis?offset=WyJmYjU3MjA1Ni1mODY1LTQ3"
+type SNIs struct {
+	Data   []*SNI `json:"data,omitempty"`
+	Total  int    `json:"total,omitempty"`
+	Next   string `json:"next,omitempty"`
+	Offset string `json:"offset,omitempty"`
+}
+
+// SNI represents a single Kong SNI object, which maps a server name to
+// the certificate Kong presents for it.
+//
+// Certificate only needs its ID set when creating or updating an SNI.
+// i.e. &SNI{Name: "example.com", Certificate: &Certificate{ID: id}}
+type SNI struct {
+	ID          string       `json:"id,omitempty"`
+	CreatedAt   int64        `json:"created_at,omitempty"`
+	Name        string       `json:"name,omitempty"`
+	Certificate *Certificate `json:"certificate,omitempty"`
+	Tags        []string     `json:"tags,omitempty"`
+}
+
+// Get queries for a single Kong SNI object, by name or id.
+//
+// Equivalent to GET /snis/{name or id}
+func (s *SNIsService) Get(sni string) (*SNI, *http.Response, error) {
+	return s.GetWithContext(context.Background(), sni)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *SNIsService) GetWithContext(ctx context.Context, sni string) (*SNI, *http.Response, error) {
+	u := fmt.Sprintf("snis/%v", sni)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(SNI)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Post creates a new Kong SNI object and returns it as stored by Kong.
+// sni.Certificate must reference an existing certificate.
+//
+// Equivalent to POST /snis
+func (s *SNIsService) Post(sni *SNI) (*SNI, *http.Response, error) {
+	return s.PostWithContext(context.Background(), sni)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *SNIsService) PostWithContext(ctx context.Context, sni *SNI) (*SNI, *http.Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, "snis", sni)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(SNI)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Patch updates an existing Kong SNI object, i.e. to point it at a new
+// certificate, and returns it as stored by Kong. At least one of sni.ID
+// or sni.Name must be specified.
+//
+// Equivalent to PATCH /snis/{id or name}
+func (s *SNIsService) Patch(sni *SNI) (*SNI, *http.Response, error) {
+	return s.PatchWithContext(context.Background(), sni)
+}
+
+// PatchWithContext is like Patch but uses ctx for the request.
+func (s *SNIsService) PatchWithContext(ctx context.Context, sni *SNI) (*SNI, *http.Response, error) {
+	var u string
+	if sni.ID != "" {
+		u = fmt.Sprintf("snis/%v", sni.ID)
+	} else if sni.Name != "" {
+		u = fmt.Sprintf("snis/%v", sni.Name)
+	} else {
+		return nil, nil, errors.New("At least one of sni.ID or sni.Name must be specified")
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, u, sni)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(SNI)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// Delete deletes a single Kong SNI object, by name or id.
+//
+// Equivalent to DELETE /snis/{name or id}
+func (s *SNIsService) Delete(sni string) (*http.Response, error) {
+	return s.DeleteWithContext(context.Background(), sni)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *SNIsService) DeleteWithContext(ctx context.Context, sni string) (*http.Response, error) {
+	u := fmt.Sprintf("snis/%v", sni)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+
+	return resp, err
+}
+
+// SNIsGetAllOptions specifies optional filter parameters to the
+// SNIsService.GetAll and CertificatesSNIsService.GetAll methods.
+//
+// Additional information about filtering options can be found in
+// the Kong documentation at:
+// https://getkong.org/docs/0.13.x/admin-api/#list-snis
+type SNIsGetAllOptions struct {
+	Tags   string `url:"tags,omitempty"`   // A filter on the list based on tags, i.e. "a,b" or "a/b". Requires Kong 1.1 or later.
+	Size   int    `url:"size,omitempty"`   // A limit on the number of objects to be returned.
+	Offset string `url:"offset,omitempty"` // A cursor used for pagination. offset is an object identifier that defines a place in the list.
+}
+
+// GetAll queries for all Kong SNI objects.
+// This query can be filtered by supplying the SNIsGetAllOptions struct.
+//
+// Equivalent to GET /snis?uri=params&from=opt
+func (s *SNIsService) GetAll(opt *SNIsGetAllOptions) (*SNIs, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *SNIsService) GetAllWithContext(ctx context.Context, opt *SNIsGetAllOptions) (*SNIs, *http.Response, error) {
+	u, err := addOptions("snis", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	snis := new(SNIs)
+	resp, err := s.client.Do(req, snis)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return snis, resp, err
+}
+
+// newSNIsIterator builds an Iterator on top of getAll, which is shared
+// between the '/snis' and '/certificates/{id}/snis' listings.
+func newSNIsIterator(opt *SNIsGetAllOptions, getAll func(*SNIsGetAllOptions) (*SNIs, error)) *Iterator[SNI] {
+	o := new(SNIsGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+
+	return newIterator(o.Offset, func(offset string) ([]*SNI, string, error) {
+		o.Offset = offset
+		snis, err := getAll(o)
+		if err != nil {
+			return nil, "", err
+		}
+		return snis.Data, nextOffset(snis.Next, snis.Offset), nil
+	})
+}
+
+// Iterator returns an Iterator over all Kong SNI objects.
+// opt.Size sets the page size and opt.Offset the starting point.
+func (s *SNIsService) Iterator(opt *SNIsGetAllOptions) *Iterator[SNI] {
+	return s.IteratorWithContext(context.Background(), opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *SNIsService) IteratorWithContext(ctx context.Context, opt *SNIsGetAllOptions) *Iterator[SNI] {
+	return newSNIsIterator(opt, func(o *SNIsGetAllOptions) (*SNIs, error) {
+		snis, _, err := s.GetAllWithContext(ctx, o)
+		return snis, err
+	})
+}
+
+// ListAll follows Kong's pagination cursor and returns every SNI object
+// matching opt. If max is greater than zero no more than max objects are
+// returned.
+func (s *SNIsService) ListAll(opt *SNIsGetAllOptions, max int) ([]*SNI, error) {
+	return s.ListAllWithContext(context.Background(), opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *SNIsService) ListAllWithContext(ctx context.Context, opt *SNIsGetAllOptions, max int) ([]*SNI, error) {
+	return s.IteratorWithContext(ctx, opt).all(max)
+}
@@ -0,0 +1,148 @@
+package kong
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSNIsService_Get(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/snis/example.com", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"s","name":"example.com","certificate":{"id":"c"}}`)
+	})
+
+	sni, _, err := client.SNIs.Get("example.com")
+	if err != nil {
+		t.Errorf("SNIs.Get returned error: %v", err)
+	}
+
+	want := &SNI{ID: "s", Name: "example.com", Certificate: &Certificate{ID: "c"}}
+	if !reflect.DeepEqual(sni, want) {
+		t.Errorf("SNIs.Get returned %+v, want %+v", sni, want)
+	}
+}
+
+func TestSNIsService_Get_badStatusCode(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/snis/example.com", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		fmt.Fprint(w, `{"message":"Not found"}`)
+	})
+
+	_, _, err := client.SNIs.Get("example.com")
+	if _, ok := err.(*NotFoundError); !ok {
+		t.Errorf("SNIs.Get returned error %v, want *NotFoundError", err)
+	}
+}
+
+func TestSNIsService_Post(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/snis", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"name":"example.com","certificate":{"id":"c"}}`+"\n")
+		w.WriteHeader(201)
+		fmt.Fprint(w, `{"id":"s","name":"example.com","certificate":{"id":"c"}}`)
+	})
+
+	sni, _, err := client.SNIs.Post(&SNI{Name: "example.com", Certificate: &Certificate{ID: "c"}})
+	if err != nil {
+		t.Errorf("SNIs.Post returned error: %v", err)
+	}
+
+	want := &SNI{ID: "s", Name: "example.com", Certificate: &Certificate{ID: "c"}}
+	if !reflect.DeepEqual(sni, want) {
+		t.Errorf("SNIs.Post returned %+v, want %+v", sni, want)
+	}
+}
+
+func TestSNIsService_Patch_byName(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/snis/example.com", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"name":"example.com","certificate":{"id":"c2"}}`+"\n")
+		fmt.Fprint(w, `{"id":"s","name":"example.com","certificate":{"id":"c2"}}`)
+	})
+
+	_, _, err := client.SNIs.Patch(&SNI{Name: "example.com", Certificate: &Certificate{ID: "c2"}})
+	if err != nil {
+		t.Errorf("SNIs.Patch returned error: %v", err)
+	}
+}
+
+func TestSNIsService_Patch_missingIDOrName(t *testing.T) {
+	_, _, err := client.SNIs.Patch(&SNI{})
+	if err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestSNIsService_Delete(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/snis/example.com", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(204)
+	})
+
+	_, err := client.SNIs.Delete("example.com")
+	if err != nil {
+		t.Errorf("SNIs.Delete returned error: %v", err)
+	}
+}
+
+func TestSNIsService_GetAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/snis", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"size": "2", "offset": "o"})
+		fmt.Fprint(w, `{"data":[{"name":"a"},{"name":"b"}],"next":"/snis?offset=o2"}`)
+	})
+
+	snis, _, err := client.SNIs.GetAll(&SNIsGetAllOptions{Size: 2, Offset: "o"})
+	if err != nil {
+		t.Errorf("SNIs.GetAll returned error: %v", err)
+	}
+
+	want := &SNIs{Data: []*SNI{{Name: "a"}, {Name: "b"}}, Next: "/snis?offset=o2"}
+	if !reflect.DeepEqual(snis, want) {
+		t.Errorf("SNIs.GetAll returned %+v, want %+v", snis, want)
+	}
+}
+
+func TestSNIsService_ListAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/snis", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprint(w, `{"data":[{"name":"a"}],"next":"/snis?offset=o"}`)
+		case "o":
+			fmt.Fprint(w, `{"data":[{"name":"b"}]}`)
+		}
+	})
+
+	snis, err := client.SNIs.ListAll(nil, 0)
+	if err != nil {
+		t.Errorf("SNIs.ListAll returned error: %v", err)
+	}
+
+	want := []*SNI{{Name: "a"}, {Name: "b"}}
+	if !reflect.DeepEqual(snis, want) {
+		t.Errorf("SNIs.ListAll returned %+v, want %+v", snis, want)
+	}
+}
@@ -0,0 +1,485 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/nccurry/go-kong/kong"
+)
+
+// Op is the kind of operation a Change performs.
+type Op int
+
+const (
+	Create Op = iota
+	Update
+	Delete
+)
+
+func (op Op) String() string {
+	switch op {
+	case Create:
+		return "create"
+	case Update:
+		return "update"
+	case Delete:
+		return "delete"
+	}
+	return fmt.Sprintf("Op(%d)", int(op))
+}
+
+// symbol is the prefix a change is printed with.
+func (op Op) symbol() string {
+	switch op {
+	case Create:
+		return "+"
+	case Update:
+		return "~"
+	}
+	return "-"
+}
+
+// Kind is the type of entity a Change applies to.
+type Kind string
+
+const (
+	KindApi      Kind = "api"
+	KindConsumer Kind = "consumer"
+	KindACL      Kind = "acl"
+	KindJWT      Kind = "jwt"
+	KindKeyAuth  Kind = "key-auth"
+	KindPlugin   Kind = "plugin"
+	KindUpstream Kind = "upstream"
+	KindTarget   Kind = "target"
+)
+
+// Change is a single operation on a single entity.
+//
+// Old and New hold the entity as it currently is and as it is desired,
+// Old being nil when creating and New being nil when deleting. Their type
+// depends on Kind:
+//
+//	KindApi      *Api
+//	KindConsumer *Consumer
+//	KindACL      *kong.ConsumerACLConfig
+//	KindJWT      *kong.ConsumerJWTConfig
+//	KindKeyAuth  *kong.ConsumerKeyAuthConfig
+//	KindPlugin   *Plugin
+//	KindUpstream *Upstream
+//	KindTarget   *kong.Target
+type Change struct {
+	Op     Op
+	Kind   Kind
+	Name   string // The name, username, group, key or target identifying the entity.
+	Parent string // The api, consumer or upstream the entity belongs to, if any.
+	Old    interface{}
+	New    interface{}
+}
+
+func (c *Change) String() string {
+	return fmt.Sprintf("%v %v", c.Op, c.title())
+}
+
+// title names the entity c applies to, i.e. "plugin acl (api mockbin)".
+func (c *Change) title() string {
+	s := fmt.Sprintf("%v %v", c.Kind, c.Name)
+	if scope := c.scope(); scope != "" {
+		s += " (" + scope + ")"
+	}
+	return s
+}
+
+// scope names the entities c.Name is scoped to.
+func (c *Change) scope() string {
+	var scope []string
+	switch c.Kind {
+	case KindACL, KindJWT, KindKeyAuth:
+		scope = append(scope, "consumer "+c.Parent)
+	case KindTarget:
+		scope = append(scope, "upstream "+c.Parent)
+	case KindPlugin:
+		if c.Parent != "" {
+			scope = append(scope, "api "+c.Parent)
+		}
+		if p := c.plugin(); p.Consumer != "" {
+			scope = append(scope, "consumer "+p.Consumer)
+		}
+	}
+	return strings.Join(scope, ", ")
+}
+
+// plugin returns the plugin a KindPlugin change applies to.
+func (c *Change) plugin() *Plugin {
+	if c.New != nil {
+		return c.New.(*Plugin)
+	}
+	return c.Old.(*Plugin)
+}
+
+// Plan is the ordered list of changes which turns one Config into
+// another.
+//
+// Deletions come first, dependent entities before the ones they depend
+// on, so that names and keys are released before they are reused.
+// Creations and updates follow in dependency order: upstreams, targets,
+// apis, consumers, credentials and finally plugins.
+type Plan struct {
+	Changes []*Change
+}
+
+// Empty reports whether the plan has nothing to do.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Print writes a human readable summary of the plan to w.
+func (p *Plan) Print(w io.Writer) error {
+	if p.Empty() {
+		_, err := fmt.Fprintln(w, "No changes.")
+		return err
+	}
+
+	var count [3]int
+	for _, c := range p.Changes {
+		count[c.Op]++
+
+		if _, err := fmt.Fprintln(w, c.Op.symbol(), c.title()); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "\nPlan: %d to create, %d to update, %d to delete.\n", count[Create], count[Update], count[Delete])
+	return err
+}
+
+// Stages of a plan, in dependency order.
+const (
+	stageUpstream = iota
+	stageTarget
+	stageApi
+	stageConsumer
+	stageCredential
+	stagePlugin
+	numStages
+)
+
+// differ accumulates the changes of a plan by stage.
+type differ struct {
+	deletes [numStages][]*Change
+	changes [numStages][]*Change
+}
+
+func (d *differ) add(stage int, c *Change) {
+	if c.Op == Delete {
+		d.deletes[stage] = append(d.deletes[stage], c)
+	} else {
+		d.changes[stage] = append(d.changes[stage], c)
+	}
+}
+
+func (d *differ) plan() *Plan {
+	plan := new(Plan)
+	for stage := numStages - 1; stage >= 0; stage-- {
+		plan.Changes = append(plan.Changes, d.deletes[stage]...)
+	}
+	for stage := 0; stage < numStages; stage++ {
+		plan.Changes = append(plan.Changes, d.changes[stage]...)
+	}
+	return plan
+}
+
+// Diff computes the Plan which turns current into desired. Entities of
+// current which are missing from desired are deleted.
+func Diff(current, desired *Config) *Plan {
+	if current == nil {
+		current = new(Config)
+	}
+	if desired == nil {
+		desired = new(Config)
+	}
+
+	d := new(differ)
+	d.apis(current.Apis, desired.Apis)
+	d.plugins("", current.Plugins, desired.Plugins)
+	d.consumers(current.Consumers, desired.Consumers)
+	d.upstreams(current.Upstreams, desired.Upstreams)
+
+	return d.plan()
+}
+
+func (d *differ) apis(current, desired []*Api) {
+	have := make(map[string]*Api)
+	for _, a := range current {
+		have[a.Name] = a
+	}
+
+	for _, want := range desired {
+		got, ok := have[want.Name]
+		delete(have, want.Name)
+
+		if !ok {
+			d.add(stageApi, &Change{Op: Create, Kind: KindApi, Name: want.Name, New: want})
+			got = new(Api)
+		} else if !covers(got.Api, want.Api) {
+			d.add(stageApi, &Change{Op: Update, Kind: KindApi, Name: want.Name, Old: got, New: want})
+		}
+		d.plugins(want.Name, got.Plugins, want.Plugins)
+	}
+
+	for _, got := range current {
+		if _, ok := have[got.Name]; ok {
+			d.plugins(got.Name, got.Plugins, nil)
+			d.add(stageApi, &Change{Op: Delete, Kind: KindApi, Name: got.Name, Old: got})
+		}
+	}
+}
+
+// plugins diffs the plugins applied to the named api, or to every api
+// when api is empty.
+func (d *differ) plugins(api string, current, desired []*Plugin) {
+	key := func(p *Plugin) string { return p.Consumer + "/" + p.Name }
+
+	have := make(map[string]*Plugin)
+	for _, p := range current {
+		have[key(p)] = p
+	}
+
+	for _, want := range desired {
+		got, ok := have[key(want)]
+		delete(have, key(want))
+
+		if !ok {
+			d.add(stagePlugin, &Change{Op: Create, Kind: KindPlugin, Name: want.Name, Parent: api, New: want})
+		} else if !pluginCovers(got, want) {
+			d.add(stagePlugin, &Change{Op: Update, Kind: KindPlugin, Name: want.Name, Parent: api, Old: got, New: want})
+		}
+	}
+
+	for _, got := range current {
+		if _, ok := have[key(got)]; ok {
+			d.add(stagePlugin, &Change{Op: Delete, Kind: KindPlugin, Name: got.Name, Parent: api, Old: got})
+		}
+	}
+}
+
+func (d *differ) consumers(current, desired []*Consumer) {
+	have := make(map[string]*Consumer)
+	for _, c := range current {
+		have[c.Username] = c
+	}
+
+	for _, want := range desired {
+		got, ok := have[want.Username]
+		delete(have, want.Username)
+
+		if !ok {
+			d.add(stageConsumer, &Change{Op: Create, Kind: KindConsumer, Name: want.Username, New: want})
+			got = new(Consumer)
+		} else if !covers(got.Consumer, want.Consumer) {
+			d.add(stageConsumer, &Change{Op: Update, Kind: KindConsumer, Name: want.Username, Old: got, New: want})
+		}
+		d.credentials(want.Username, got, want)
+	}
+
+	for _, got := range current {
+		if _, ok := have[got.Username]; ok {
+			d.credentials(got.Username, got, new(Consumer))
+			d.add(stageConsumer, &Change{Op: Delete, Kind: KindConsumer, Name: got.Username, Old: got})
+		}
+	}
+}
+
+// credentials diffs the acls, jwts and key-auths of the named consumer.
+// acls and key-auths are fully identified by their group or key, so they
+// are only ever created or deleted.
+func (d *differ) credentials(consumer string, current, desired *Consumer) {
+	acls := make(map[string]*kong.ConsumerACLConfig)
+	for _, acl := range current.ACLs {
+		acls[acl.Group] = acl
+	}
+	for _, want := range desired.ACLs {
+		if _, ok := acls[want.Group]; !ok {
+			d.add(stageCredential, &Change{Op: Create, Kind: KindACL, Name: want.Group, Parent: consumer, New: want})
+		}
+		delete(acls, want.Group)
+	}
+	for _, got := range current.ACLs {
+		if _, ok := acls[got.Group]; ok {
+			d.add(stageCredential, &Change{Op: Delete, Kind: KindACL, Name: got.Group, Parent: consumer, Old: got})
+		}
+	}
+
+	jwts := make(map[string]*kong.ConsumerJWTConfig)
+	for _, jwt := range current.JWTs {
+		jwts[jwt.Key] = jwt
+	}
+	for _, want := range desired.JWTs {
+		got, ok := jwts[want.Key]
+		if !ok {
+			d.add(stageCredential, &Change{Op: Create, Kind: KindJWT, Name: want.Key, Parent: consumer, New: want})
+		} else if !covers(got, want) {
+			d.add(stageCredential, &Change{Op: Update, Kind: KindJWT, Name: want.Key, Parent: consumer, Old: got, New: want})
+		}
+		delete(jwts, want.Key)
+	}
+	for _, got := range current.JWTs {
+		if _, ok := jwts[got.Key]; ok {
+			d.add(stageCredential, &Change{Op: Delete, Kind: KindJWT, Name: got.Key, Parent: consumer, Old: got})
+		}
+	}
+
+	keyAuths := make(map[string]*kong.ConsumerKeyAuthConfig)
+	for _, keyAuth := range current.KeyAuths {
+		keyAuths[keyAuth.Key] = keyAuth
+	}
+	for _, want := range desired.KeyAuths {
+		if _, ok := keyAuths[want.Key]; !ok {
+			d.add(stageCredential, &Change{Op: Create, Kind: KindKeyAuth, Name: want.Key, Parent: consumer, New: want})
+		}
+		delete(keyAuths, want.Key)
+	}
+	for _, got := range current.KeyAuths {
+		if _, ok := keyAuths[got.Key]; ok {
+			d.add(stageCredential, &Change{Op: Delete, Kind: KindKeyAuth, Name: got.Key, Parent: consumer, Old: got})
+		}
+	}
+}
+
+func (d *differ) upstreams(current, desired []*Upstream) {
+	have := make(map[string]*Upstream)
+	for _, u := range current {
+		have[u.Name] = u
+	}
+
+	for _, want := range desired {
+		got, ok := have[want.Name]
+		delete(have, want.Name)
+
+		if !ok {
+			d.add(stageUpstream, &Change{Op: Create, Kind: KindUpstream, Name: want.Name, New: want})
+			got = new(Upstream)
+		} else if !covers(got.Upstream, want.Upstream) {
+			d.add(stageUpstream, &Change{Op: Update, Kind: KindUpstream, Name: want.Name, Old: got, New: want})
+		}
+		d.targets(want.Name, got.Targets, want.Targets)
+	}
+
+	for _, got := range current {
+		if _, ok := have[got.Name]; ok {
+			d.targets(got.Name, got.Targets, nil)
+			d.add(stageUpstream, &Change{Op: Delete, Kind: KindUpstream, Name: got.Name, Old: got})
+		}
+	}
+}
+
+func (d *differ) targets(upstream string, current, desired []*kong.Target) {
+	have := make(map[string]*kong.Target)
+	for _, t := range current {
+		have[t.Target] = t
+	}
+
+	for _, want := range desired {
+		got, ok := have[want.Target]
+		delete(have, want.Target)
+
+		if !ok {
+			d.add(stageTarget, &Change{Op: Create, Kind: KindTarget, Name: want.Target, Parent: upstream, New: want})
+		} else if want.Weight != 0 && want.Weight != got.Weight {
+			d.add(stageTarget, &Change{Op: Update, Kind: KindTarget, Name: want.Target, Parent: upstream, Old: got, New: want})
+		}
+	}
+
+	for _, got := range current {
+		if _, ok := have[got.Target]; ok {
+			d.add(stageTarget, &Change{Op: Delete, Kind: KindTarget, Name: got.Target, Parent: upstream, Old: got})
+		}
+	}
+}
+
+// pluginCovers reports whether got already matches want. Only the config
+// keys want sets are compared, as Kong fills in defaults for the rest.
+func pluginCovers(got, want *Plugin) bool {
+	if want.Enabled != nil && (got.Enabled == nil || *got.Enabled != *want.Enabled) {
+		return false
+	}
+	return want.Config == nil || subset(normalize(want.Config), normalize(got.Config))
+}
+
+// covers reports whether every field set in want holds the same value in
+// got. Top level fields left at their zero value in want are skipped.
+func covers(got, want interface{}) bool {
+	return subset(fields(want), fields(got))
+}
+
+// fields returns the JSON representation of v as a map, without the top
+// level fields which hold a zero value.
+func fields(v interface{}) map[string]interface{} {
+	m, _ := normalize(v).(map[string]interface{})
+	for k, f := range m {
+		switch f := f.(type) {
+		case nil:
+			delete(m, k)
+		case bool:
+			if !f {
+				delete(m, k)
+			}
+		case float64:
+			if f == 0 {
+				delete(m, k)
+			}
+		case string:
+			if f == "" {
+				delete(m, k)
+			}
+		}
+	}
+	return m
+}
+
+// normalize converts v to the generic form encoding/json decodes to, so
+// values coming from structs and from decoded documents compare equal.
+func normalize(v interface{}) interface{} {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	var n interface{}
+	if err := json.Unmarshal(b, &n); err != nil {
+		return nil
+	}
+	return n
+}
+
+// subset reports whether got holds everything want does. Objects may
+// hold additional keys, every other value must be equal.
+func subset(want, got interface{}) bool {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			return len(w) == 0 && got == nil
+		}
+		for k, v := range w {
+			if !subset(v, g[k]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok || len(g) != len(w) {
+			return len(w) == 0 && got == nil
+		}
+		for i := range w {
+			if !subset(w[i], g[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(want, got)
+}
@@ -0,0 +1,216 @@
+package state
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/nccurry/go-kong/kong"
+)
+
+// titles lists the changes of p as strings, in order.
+func titles(p *Plan) []string {
+	var s []string
+	for _, c := range p.Changes {
+		s = append(s, c.String())
+	}
+	return s
+}
+
+func TestDiff_empty(t *testing.T) {
+	plan := Diff(nil, nil)
+	if !plan.Empty() {
+		t.Errorf("Diff returned %v, want no changes", titles(plan))
+	}
+}
+
+func TestDiff_order(t *testing.T) {
+	current := &Config{
+		Apis: []*Api{{
+			Api:     kong.Api{ID: "a1", Name: "old"},
+			Plugins: []*Plugin{{ID: "p1", Name: "acl"}},
+		}},
+		Consumers: []*Consumer{{
+			Consumer: kong.Consumer{ID: "c1", Username: "alice"},
+			KeyAuths: []*kong.ConsumerKeyAuthConfig{{ID: "k1", Key: "shared"}},
+		}},
+		Upstreams: []*Upstream{{
+			Upstream: kong.Upstream{ID: "u1", Name: "old"},
+			Targets:  []*kong.Target{{Target: "10.0.0.1:80"}},
+		}},
+	}
+	desired := &Config{
+		Apis: []*Api{{
+			Api:     kong.Api{Name: "new"},
+			Plugins: []*Plugin{{Name: "key-auth", Consumer: "bob"}},
+		}},
+		Consumers: []*Consumer{{
+			Consumer: kong.Consumer{Username: "bob"},
+			KeyAuths: []*kong.ConsumerKeyAuthConfig{{Key: "shared"}},
+		}},
+		Upstreams: []*Upstream{{
+			Upstream: kong.Upstream{Name: "new"},
+			Targets:  []*kong.Target{{Target: "10.0.0.2:80"}},
+		}},
+	}
+
+	want := []string{
+		"delete plugin acl (api old)",
+		"delete key-auth shared (consumer alice)",
+		"delete consumer alice",
+		"delete api old",
+		"delete target 10.0.0.1:80 (upstream old)",
+		"delete upstream old",
+		"create upstream new",
+		"create target 10.0.0.2:80 (upstream new)",
+		"create api new",
+		"create consumer bob",
+		"create key-auth shared (consumer bob)",
+		"create plugin key-auth (api new, consumer bob)",
+	}
+	if got := titles(Diff(current, desired)); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff returned %q, want %q", got, want)
+	}
+}
+
+func TestDiff_unchanged(t *testing.T) {
+	current := &Config{
+		Apis: []*Api{{
+			Api: kong.Api{ID: "a1", Name: "mockbin", UpstreamURL: "http://mockbin.org", Retries: 5, UpstreamReadTimeout: 60000},
+			Plugins: []*Plugin{{
+				ID:      "p1",
+				Name:    "rate-limiting",
+				Enabled: kong.Bool(true),
+				Config:  map[string]interface{}{"minute": float64(20), "policy": "cluster"},
+			}},
+		}},
+		Upstreams: []*Upstream{{
+			Upstream: kong.Upstream{ID: "u1", Name: "service.v1", Slots: 1000},
+			Targets:  []*kong.Target{{Target: "10.0.0.1:80", Weight: 100}},
+		}},
+	}
+	desired := &Config{
+		Apis: []*Api{{
+			Api: kong.Api{Name: "mockbin", UpstreamURL: "http://mockbin.org"},
+			Plugins: []*Plugin{{
+				Name:   "rate-limiting",
+				Config: map[string]interface{}{"minute": 20},
+			}},
+		}},
+		Upstreams: []*Upstream{{
+			Upstream: kong.Upstream{Name: "service.v1"},
+			Targets:  []*kong.Target{{Target: "10.0.0.1:80"}},
+		}},
+	}
+
+	plan := Diff(current, desired)
+	if !plan.Empty() {
+		t.Errorf("Diff returned %v, want no changes", titles(plan))
+	}
+}
+
+func TestDiff_update(t *testing.T) {
+	current := &Config{
+		Apis: []*Api{{
+			Api: kong.Api{ID: "a1", Name: "mockbin", UpstreamURL: "http://mockbin.org"},
+			Plugins: []*Plugin{{
+				ID:      "p1",
+				Name:    "rate-limiting",
+				Enabled: kong.Bool(true),
+				Config:  map[string]interface{}{"minute": float64(20)},
+			}},
+		}},
+		Consumers: []*Consumer{{
+			Consumer: kong.Consumer{ID: "c1", Username: "bob"},
+			JWTs:     []*kong.ConsumerJWTConfig{{ID: "j1", Key: "iss", Secret: "a"}},
+		}},
+		Plugins: []*Plugin{{ID: "p2", Name: "cors", Enabled: kong.Bool(true)}},
+		Upstreams: []*Upstream{{
+			Upstream: kong.Upstream{ID: "u1", Name: "service.v1", Slots: 1000},
+			Targets:  []*kong.Target{{Target: "10.0.0.1:80", Weight: 100}},
+		}},
+	}
+	desired := &Config{
+		Apis: []*Api{{
+			Api: kong.Api{Name: "mockbin", UpstreamURL: "http://mockbin.com"},
+			Plugins: []*Plugin{{
+				Name:   "rate-limiting",
+				Config: map[string]interface{}{"minute": 30},
+			}},
+		}},
+		Consumers: []*Consumer{{
+			Consumer: kong.Consumer{Username: "bob", CustomID: "b"},
+			JWTs:     []*kong.ConsumerJWTConfig{{Key: "iss", Secret: "b"}},
+		}},
+		Plugins: []*Plugin{{Name: "cors", Enabled: kong.Bool(false)}},
+		Upstreams: []*Upstream{{
+			Upstream: kong.Upstream{Name: "service.v1", Slots: 2000},
+			Targets:  []*kong.Target{{Target: "10.0.0.1:80", Weight: 50}},
+		}},
+	}
+
+	want := []string{
+		"update upstream service.v1",
+		"update target 10.0.0.1:80 (upstream service.v1)",
+		"update api mockbin",
+		"update consumer bob",
+		"update jwt iss (consumer bob)",
+		"update plugin rate-limiting (api mockbin)",
+		"update plugin cors",
+	}
+	if got := titles(Diff(current, desired)); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff returned %q, want %q", got, want)
+	}
+}
+
+func TestPlan_Print(t *testing.T) {
+	plan := &Plan{Changes: []*Change{
+		{Op: Delete, Kind: KindPlugin, Name: "acl", Parent: "mockbin", Old: &Plugin{Name: "acl", Consumer: "bob"}},
+		{Op: Create, Kind: KindApi, Name: "mockbin", New: &Api{}},
+		{Op: Update, Kind: KindTarget, Name: "10.0.0.1:80", Parent: "service.v1", New: &kong.Target{}},
+	}}
+
+	var buf bytes.Buffer
+	if err := plan.Print(&buf); err != nil {
+		t.Fatalf("Plan.Print returned error: %v", err)
+	}
+
+	want := `- plugin acl (api mockbin, consumer bob)
++ api mockbin
+~ target 10.0.0.1:80 (upstream service.v1)
+
+Plan: 1 to create, 1 to update, 1 to delete.
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Plan.Print wrote %q, want %q", got, want)
+	}
+}
+
+func TestPlan_Print_empty(t *testing.T) {
+	var buf bytes.Buffer
+	new(Plan).Print(&buf)
+
+	if got, want := buf.String(), "No changes.\n"; got != want {
+		t.Errorf("Plan.Print wrote %q, want %q", got, want)
+	}
+}
+
+func TestSubset(t *testing.T) {
+	tests := []struct {
+		want, got interface{}
+		ok        bool
+	}{
+		{map[string]interface{}{"a": 1.0}, map[string]interface{}{"a": 1.0, "b": 2.0}, true},
+		{map[string]interface{}{"a": 1.0}, map[string]interface{}{"a": 2.0}, false},
+		{map[string]interface{}{"a": false}, map[string]interface{}{}, false},
+		{map[string]interface{}{"a": map[string]interface{}{"b": "c"}}, map[string]interface{}{"a": map[string]interface{}{"b": "c", "d": "e"}}, true},
+		{[]interface{}{"a"}, []interface{}{"a", "b"}, false},
+		{[]interface{}{}, nil, true},
+	}
+
+	for _, tt := range tests {
+		if got := subset(tt.want, tt.got); got != tt.ok {
+			t.Errorf("subset(%v, %v) returned %v, want %v", tt.want, tt.got, got, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ghodss/yaml"
+	"github.com/nccurry/go-kong/kong"
+)
+
+// Format is the encoding a Config is written in.
+type Format int
+
+const (
+	YAML Format = iota
+	JSON
+)
+
+// DumpOptions controls the output of Dump.
+type DumpOptions struct {
+	Format   Format // The encoding of the document, YAML by default.
+	StripIDs bool   // Leave out ids and creation times, so the document can be applied to another Kong instance.
+}
+
+// Dump writes the configuration of the Kong instance behind client to w
+// as a single document, which Load reads back. It is meant for backups
+// and for bootstrapping a declarative configuration from an existing
+// cluster.
+func Dump(ctx context.Context, client *kong.Client, w io.Writer, opt *DumpOptions) error {
+	if opt == nil {
+		opt = new(DumpOptions)
+	}
+
+	config, err := Fetch(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	if opt.StripIDs {
+		config.StripIDs()
+	}
+
+	return config.Encode(w, opt.Format)
+}
+
+// Encode writes c to w in the given format.
+func (c *Config) Encode(w io.Writer, format Format) error {
+	var b []byte
+	var err error
+
+	switch format {
+	case YAML:
+		b, err = yaml.Marshal(c)
+	case JSON:
+		b, err = json.MarshalIndent(c, "", "  ")
+		b = append(b, '\n')
+	default:
+		return fmt.Errorf("unknown format %d", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	return err
+}
+
+// StripIDs clears the ids and creation times Kong assigned to the
+// entities of c, as well as the ids they reference each other by.
+func (c *Config) StripIDs() {
+	for _, a := range c.Apis {
+		a.ID, a.CreatedAt = "", 0
+		stripPluginIDs(a.Plugins)
+	}
+	stripPluginIDs(c.Plugins)
+
+	for _, cs := range c.Consumers {
+		cs.ID, cs.CreatedAt = "", 0
+		for _, acl := range cs.ACLs {
+			acl.ID, acl.CreatedAt, acl.ConsumerID = "", 0, ""
+		}
+		for _, jwt := range cs.JWTs {
+			jwt.ID, jwt.CreatedAt, jwt.ConsumerID = "", 0, ""
+		}
+		for _, keyAuth := range cs.KeyAuths {
+			keyAuth.ID, keyAuth.CreatedAt, keyAuth.ConsumerID = "", 0, ""
+		}
+	}
+
+	for _, u := range c.Upstreams {
+		u.ID, u.CreatedAt = "", 0
+		for _, t := range u.Targets {
+			t.ID, t.CreatedAt, t.UpstreamID = "", 0, ""
+		}
+	}
+}
+
+func stripPluginIDs(plugins []*Plugin) {
+	for _, p := range plugins {
+		p.ID, p.CreatedAt = "", 0
+	}
+}
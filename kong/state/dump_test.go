@@ -0,0 +1,89 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestDump(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	stubConfig()
+
+	var buf bytes.Buffer
+	if err := Dump(context.Background(), client, &buf, &DumpOptions{StripIDs: true}); err != nil {
+		t.Fatalf("Dump returned error: %v", err)
+	}
+
+	want := `apis:
+- http_if_terminated: false
+  https_only: false
+  name: mockbin
+  plugins:
+  - consumer: bob
+    name: rate-limiting
+  retries: 0
+  strip_uri: false
+  upstream_connect_timeout: 0
+  upstream_read_timeout: 0
+  upstream_send_timeout: 0
+  uris: null
+- http_if_terminated: false
+  https_only: false
+  name: z
+  retries: 0
+  strip_uri: false
+  upstream_connect_timeout: 0
+  upstream_read_timeout: 0
+  upstream_send_timeout: 0
+  uris: null
+consumers:
+- acls:
+  - group: admins
+  key_auths:
+  - key: secret
+  username: bob
+plugins:
+- name: correlation-id
+upstreams:
+- name: service.v1
+  targets:
+  - target: 10.0.0.1:80
+    weight: 50
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Dump wrote:\n%v\nwant:\n%v", got, want)
+	}
+}
+
+func TestDump_json(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	stubConfig()
+
+	var buf bytes.Buffer
+	if err := Dump(context.Background(), client, &buf, &DumpOptions{Format: JSON}); err != nil {
+		t.Fatalf("Dump returned error: %v", err)
+	}
+
+	// A dump keeps its ids unless asked not to, and loads back unchanged
+	dumped, err := Load(&buf)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	current, _ := Fetch(context.Background(), client)
+	if !reflect.DeepEqual(dumped, current) {
+		t.Errorf("Load of the dump returned %+v, want %+v", dumped, current)
+	}
+}
+
+func TestConfig_Encode_unknownFormat(t *testing.T) {
+	if err := new(Config).Encode(new(bytes.Buffer), Format(7)); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
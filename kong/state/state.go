@@ -0,0 +1,338 @@
+// Package state manages a Kong instance declaratively.
+//
+// The desired configuration is described by a Config, usually loaded from
+// a YAML or JSON file kept under version control. Fetch reads the current
+// configuration through a kong.Client, Diff computes the Plan which turns
+// one into the other and Plan.Apply carries it out.
+//
+//	desired, _ := state.LoadFile("kong.yaml")
+//	plan, err := state.Sync(ctx, client, desired, &state.SyncOptions{DryRun: true, Out: os.Stdout})
+package state
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/nccurry/go-kong/kong"
+)
+
+// Config describes the apis, consumers, plugins and upstreams of a Kong
+// instance.
+//
+// Entities reference each other by name rather than by id, so the same
+// Config can be applied to any Kong instance: apis are identified by
+// name, consumers by username and upstreams by name. Plugins are listed
+// under the api they apply to, or in Config.Plugins when they apply to
+// every api, and name the consumer they apply to by username.
+//
+// Fields left at their zero value are not compared against Kong, so
+// values Kong fills in with defaults do not need to be repeated.
+type Config struct {
+	Apis      []*Api      `json:"apis,omitempty"`
+	Consumers []*Consumer `json:"consumers,omitempty"`
+	Plugins   []*Plugin   `json:"plugins,omitempty"`
+	Upstreams []*Upstream `json:"upstreams,omitempty"`
+}
+
+// Api is a Kong api object together with the plugins applied to it.
+type Api struct {
+	kong.Api
+	Plugins []*Plugin `json:"plugins,omitempty"`
+}
+
+// Consumer is a Kong consumer object together with its credentials.
+type Consumer struct {
+	kong.Consumer
+	ACLs     []*kong.ConsumerACLConfig     `json:"acls,omitempty"`
+	JWTs     []*kong.ConsumerJWTConfig     `json:"jwts,omitempty"`
+	KeyAuths []*kong.ConsumerKeyAuthConfig `json:"key_auths,omitempty"`
+}
+
+// Upstream is a Kong upstream object together with its targets.
+type Upstream struct {
+	kong.Upstream
+	Targets []*kong.Target `json:"targets,omitempty"`
+}
+
+// Plugin is a Kong plugin object. A plugin is identified by its Name, the
+// api it is listed under and its Consumer, which holds the username of
+// the consumer the plugin applies to, if any.
+type Plugin struct {
+	ID        string                 `json:"id,omitempty"`
+	CreatedAt int                    `json:"created_at,omitempty"`
+	Name      string                 `json:"name"`
+	Consumer  string                 `json:"consumer,omitempty"`
+	Enabled   *bool                  `json:"enabled,omitempty"`
+	Config    map[string]interface{} `json:"config,omitempty"`
+}
+
+// Load reads a Config from r, which may hold either YAML or JSON.
+// Unknown fields are rejected to catch typos early.
+func Load(r io.Reader) (*Config, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	config := new(Config)
+	if err := yaml.Unmarshal(b, config, yaml.DisallowUnknownFields); err != nil {
+		return nil, err
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// LoadFile reads a Config from the YAML or JSON file at path.
+func LoadFile(path string) (*Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	config, err := Load(f)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// validate checks that every entity carries the name it is identified by
+// and that no name is used twice.
+func (c *Config) validate() error {
+	apis := make(map[string]bool)
+	for _, a := range c.Apis {
+		if a.Name == "" {
+			return fmt.Errorf("api without a name")
+		}
+		if apis[a.Name] {
+			return fmt.Errorf("duplicate api %q", a.Name)
+		}
+		apis[a.Name] = true
+	}
+
+	consumers := make(map[string]bool)
+	for _, cs := range c.Consumers {
+		if cs.Username == "" {
+			return fmt.Errorf("consumer without a username")
+		}
+		if consumers[cs.Username] {
+			return fmt.Errorf("duplicate consumer %q", cs.Username)
+		}
+		consumers[cs.Username] = true
+
+		// Credentials are identified by their group or key, which Kong
+		// would otherwise generate
+		for _, acl := range cs.ACLs {
+			if acl.Group == "" {
+				return fmt.Errorf("acl without a group on consumer %q", cs.Username)
+			}
+		}
+		for _, jwt := range cs.JWTs {
+			if jwt.Key == "" {
+				return fmt.Errorf("jwt without a key on consumer %q", cs.Username)
+			}
+		}
+		for _, keyAuth := range cs.KeyAuths {
+			if keyAuth.Key == "" {
+				return fmt.Errorf("key-auth without a key on consumer %q", cs.Username)
+			}
+		}
+	}
+
+	// Plugins may only reference consumers the config declares, as any
+	// other consumer would be deleted
+	if err := validatePlugins(c.Plugins, "", consumers); err != nil {
+		return err
+	}
+	for _, a := range c.Apis {
+		if err := validatePlugins(a.Plugins, fmt.Sprintf(" on api %q", a.Name), consumers); err != nil {
+			return err
+		}
+	}
+
+	upstreams := make(map[string]bool)
+	for _, u := range c.Upstreams {
+		if u.Name == "" {
+			return fmt.Errorf("upstream without a name")
+		}
+		if upstreams[u.Name] {
+			return fmt.Errorf("duplicate upstream %q", u.Name)
+		}
+		upstreams[u.Name] = true
+
+		for _, t := range u.Targets {
+			if t.Target == "" {
+				return fmt.Errorf("target without an address on upstream %q", u.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validatePlugins(plugins []*Plugin, on string, consumers map[string]bool) error {
+	seen := make(map[string]bool)
+	for _, p := range plugins {
+		if p.Name == "" {
+			return fmt.Errorf("plugin without a name%v", on)
+		}
+		if p.Consumer != "" && !consumers[p.Consumer] {
+			return fmt.Errorf("plugin %q%v references unknown consumer %q", p.Name, on, p.Consumer)
+		}
+		if seen[p.Consumer+"/"+p.Name] {
+			return fmt.Errorf("duplicate plugin %q%v", p.Name, on)
+		}
+		seen[p.Consumer+"/"+p.Name] = true
+	}
+	return nil
+}
+
+// Fetch reads the current configuration of the Kong instance behind
+// client. Entities are sorted by name so the result is stable.
+//
+// Plugins attached to services or routes, rather than apis, are not part
+// of a Config and are left out.
+func Fetch(ctx context.Context, client *kong.Client) (*Config, error) {
+	config := new(Config)
+
+	apis, err := client.Apis.ListAllWithContext(ctx, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("listing apis: %w", err)
+	}
+	apiNames := make(map[string]*Api)
+	for _, a := range apis {
+		api := &Api{Api: *a}
+		apiNames[a.ID] = api
+		config.Apis = append(config.Apis, api)
+	}
+
+	consumers, err := client.Consumers.ListAllWithContext(ctx, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("listing consumers: %w", err)
+	}
+	usernames := make(map[string]string)
+	for _, c := range consumers {
+		consumer, err := fetchConsumer(ctx, client, c)
+		if err != nil {
+			return nil, err
+		}
+		usernames[c.ID] = consumer.Username
+		config.Consumers = append(config.Consumers, consumer)
+	}
+
+	plugins, err := client.Plugins.ListAllWithContext(ctx, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("listing plugins: %w", err)
+	}
+	for _, p := range plugins {
+		if p.ServiceID != "" || p.RouteID != "" {
+			continue
+		}
+
+		plugin := &Plugin{
+			ID:        p.ID,
+			CreatedAt: p.CreatedAt,
+			Name:      p.Name,
+			Enabled:   p.Enabled,
+			Config:    p.Config,
+		}
+		if p.ConsumerID != "" {
+			plugin.Consumer = usernames[p.ConsumerID]
+			if plugin.Consumer == "" {
+				plugin.Consumer = p.ConsumerID
+			}
+		}
+
+		if p.ApiID == "" {
+			config.Plugins = append(config.Plugins, plugin)
+		} else if api, ok := apiNames[p.ApiID]; ok {
+			api.Plugins = append(api.Plugins, plugin)
+		}
+	}
+
+	upstreams, err := client.Upstreams.ListAllWithContext(ctx, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("listing upstreams: %w", err)
+	}
+	for _, u := range upstreams {
+		targets, _, err := client.Targets.GetAllActiveWithContext(ctx, u.ID)
+		if err != nil {
+			return nil, fmt.Errorf("listing targets of upstream %q: %w", u.Name, err)
+		}
+		config.Upstreams = append(config.Upstreams, &Upstream{Upstream: *u, Targets: targets.Data})
+	}
+
+	config.sort()
+
+	return config, nil
+}
+
+// fetchConsumer reads the credentials of c.
+func fetchConsumer(ctx context.Context, client *kong.Client, c *kong.Consumer) (*Consumer, error) {
+	consumer := &Consumer{Consumer: *c}
+	if consumer.Username == "" {
+		// Consumers created with only a custom_id still need a handle
+		consumer.Username = c.ID
+	}
+
+	acls, err := client.Consumers.Plugins.ACL.ListAllWithContext(ctx, c.ID, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("listing acls of consumer %q: %w", consumer.Username, err)
+	}
+	consumer.ACLs = acls
+
+	jwts, err := client.Consumers.Plugins.JWT.ListAllWithContext(ctx, c.ID, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("listing jwts of consumer %q: %w", consumer.Username, err)
+	}
+	consumer.JWTs = jwts
+
+	keyAuths, err := client.Consumers.Plugins.KeyAuth.ListAllWithContext(ctx, c.ID, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("listing key-auths of consumer %q: %w", consumer.Username, err)
+	}
+	consumer.KeyAuths = keyAuths
+
+	return consumer, nil
+}
+
+// sort orders every list in c by the name its entities are identified by.
+func (c *Config) sort() {
+	sort.Slice(c.Apis, func(i, j int) bool { return c.Apis[i].Name < c.Apis[j].Name })
+	for _, a := range c.Apis {
+		sortPlugins(a.Plugins)
+	}
+	sortPlugins(c.Plugins)
+
+	sort.Slice(c.Consumers, func(i, j int) bool { return c.Consumers[i].Username < c.Consumers[j].Username })
+	for _, cs := range c.Consumers {
+		sort.Slice(cs.ACLs, func(i, j int) bool { return cs.ACLs[i].Group < cs.ACLs[j].Group })
+		sort.Slice(cs.JWTs, func(i, j int) bool { return cs.JWTs[i].Key < cs.JWTs[j].Key })
+		sort.Slice(cs.KeyAuths, func(i, j int) bool { return cs.KeyAuths[i].Key < cs.KeyAuths[j].Key })
+	}
+
+	sort.Slice(c.Upstreams, func(i, j int) bool { return c.Upstreams[i].Name < c.Upstreams[j].Name })
+	for _, u := range c.Upstreams {
+		sort.Slice(u.Targets, func(i, j int) bool { return u.Targets[i].Target < u.Targets[j].Target })
+	}
+}
+
+func sortPlugins(plugins []*Plugin) {
+	sort.Slice(plugins, func(i, j int) bool {
+		if plugins[i].Name != plugins[j].Name {
+			return plugins[i].Name < plugins[j].Name
+		}
+		return plugins[i].Consumer < plugins[j].Consumer
+	})
+}
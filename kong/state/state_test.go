@@ -0,0 +1,209 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nccurry/go-kong/kong"
+)
+
+var (
+	// HTTP mux used with test server
+	mux *http.ServeMux
+
+	// Kong client talking to the test server
+	client *kong.Client
+
+	// Test server used to stub Kong resources
+	server *httptest.Server
+)
+
+// stubSetup creates a test HTTP server and a kong.Client that is
+// configured to talk to the test server.
+func stubSetup() {
+	mux = http.NewServeMux()
+	server = httptest.NewServer(mux)
+
+	client, _ = kong.NewClient(nil, server.URL)
+}
+
+func stubTeardown() {
+	server.Close()
+}
+
+// stubConfig registers handlers serving a small Kong configuration: two
+// apis, a consumer with credentials, plugins and an upstream with a target.
+func stubConfig() {
+	mux.HandleFunc("/apis", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"id":"a2","name":"z"},{"id":"a1","name":"mockbin"}]}`)
+	})
+	mux.HandleFunc("/consumers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"id":"c1","username":"bob"}]}`)
+	})
+	mux.HandleFunc("/consumers/c1/acls", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"id":"acl1","group":"admins"}]}`)
+	})
+	mux.HandleFunc("/consumers/c1/jwt", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[]}`)
+	})
+	mux.HandleFunc("/consumers/c1/key-auth", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"id":"k1","key":"secret"}]}`)
+	})
+	mux.HandleFunc("/plugins", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[
+			{"id":"p1","name":"rate-limiting","api_id":"a1","consumer_id":"c1"},
+			{"id":"p2","name":"correlation-id"},
+			{"id":"p3","name":"cors","service_id":"s1"}
+		]}`)
+	})
+	mux.HandleFunc("/upstreams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"id":"u1","name":"service.v1"}]}`)
+	})
+	mux.HandleFunc("/upstreams/u1/targets/active", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"total":1,"data":[{"id":"t1","target":"10.0.0.1:80","weight":50}]}`)
+	})
+}
+
+const sampleYAML = `
+apis:
+- name: mockbin
+  upstream_url: http://mockbin.org
+  uris: ["/mockbin"]
+  plugins:
+  - name: rate-limiting
+    consumer: bob
+    config:
+      minute: 20
+consumers:
+- username: bob
+  acls:
+  - group: admins
+  key_auths:
+  - key: secret
+plugins:
+- name: correlation-id
+upstreams:
+- name: service.v1
+  targets:
+  - target: 10.0.0.1:80
+    weight: 50
+`
+
+func TestLoad(t *testing.T) {
+	config, err := Load(strings.NewReader(sampleYAML))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	want := &Config{
+		Apis: []*Api{{
+			Api: kong.Api{Name: "mockbin", UpstreamURL: "http://mockbin.org", Uris: []string{"/mockbin"}},
+			Plugins: []*Plugin{{
+				Name:     "rate-limiting",
+				Consumer: "bob",
+				Config:   map[string]interface{}{"minute": float64(20)},
+			}},
+		}},
+		Consumers: []*Consumer{{
+			Consumer: kong.Consumer{Username: "bob"},
+			ACLs:     []*kong.ConsumerACLConfig{{Group: "admins"}},
+			KeyAuths: []*kong.ConsumerKeyAuthConfig{{Key: "secret"}},
+		}},
+		Plugins: []*Plugin{{Name: "correlation-id"}},
+		Upstreams: []*Upstream{{
+			Upstream: kong.Upstream{Name: "service.v1"},
+			Targets:  []*kong.Target{{Target: "10.0.0.1:80", Weight: 50}},
+		}},
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("Load returned %+v, want %+v", config, want)
+	}
+}
+
+func TestLoad_json(t *testing.T) {
+	config, err := Load(strings.NewReader(`{"consumers":[{"username":"bob"}]}`))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if len(config.Consumers) != 1 || config.Consumers[0].Username != "bob" {
+		t.Errorf("Load returned %+v, want consumer bob", config)
+	}
+}
+
+func TestLoad_invalid(t *testing.T) {
+	tests := []struct {
+		doc  string
+		want string
+	}{
+		{`apis: [{upstream_url: http://a}]`, "api without a name"},
+		{`apis: [{name: a}, {name: a}]`, `duplicate api "a"`},
+		{`consumers: [{custom_id: a}]`, "consumer without a username"},
+		{`consumers: [{username: a, key_auths: [{}]}]`, `key-auth without a key on consumer "a"`},
+		{`plugins: [{name: acl, consumer: bob}]`, `plugin "acl" references unknown consumer "bob"`},
+		{`apis: [{name: a, plugins: [{name: acl}, {name: acl}]}]`, `duplicate plugin "acl" on api "a"`},
+		{`upstreams: [{name: u, targets: [{weight: 10}]}]`, `target without an address on upstream "u"`},
+		{`apis: [{name: a, typo: b}]`, "unknown field"},
+	}
+
+	for _, tt := range tests {
+		_, err := Load(strings.NewReader(tt.doc))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Load(%q) returned error %v, want %q", tt.doc, err, tt.want)
+		}
+	}
+}
+
+func TestFetch(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	stubConfig()
+
+	config, err := Fetch(context.Background(), client)
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+
+	want := &Config{
+		Apis: []*Api{
+			{
+				Api:     kong.Api{ID: "a1", Name: "mockbin"},
+				Plugins: []*Plugin{{ID: "p1", Name: "rate-limiting", Consumer: "bob"}},
+			},
+			{Api: kong.Api{ID: "a2", Name: "z"}},
+		},
+		Consumers: []*Consumer{{
+			Consumer: kong.Consumer{ID: "c1", Username: "bob"},
+			ACLs:     []*kong.ConsumerACLConfig{{ID: "acl1", Group: "admins"}},
+			KeyAuths: []*kong.ConsumerKeyAuthConfig{{ID: "k1", Key: "secret"}},
+		}},
+		Plugins: []*Plugin{{ID: "p2", Name: "correlation-id"}},
+		Upstreams: []*Upstream{{
+			Upstream: kong.Upstream{ID: "u1", Name: "service.v1"},
+			Targets:  []*kong.Target{{ID: "t1", Target: "10.0.0.1:80", Weight: 50}},
+		}},
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("Fetch returned %+v, want %+v", config, want)
+	}
+}
+
+func TestFetch_error(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/apis", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, err := Fetch(context.Background(), client)
+	if err == nil || !strings.HasPrefix(err.Error(), "listing apis") {
+		t.Errorf("Fetch returned error %v, want listing apis error", err)
+	}
+}
@@ -0,0 +1,282 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nccurry/go-kong/kong"
+)
+
+// SyncOptions controls the behaviour of Sync.
+type SyncOptions struct {
+	DryRun bool      // Only compute the plan, without changing anything.
+	Out    io.Writer // Where the plan is printed before it is applied, if not nil.
+}
+
+// Sync brings the Kong instance behind client in line with desired. It
+// fetches the current configuration, computes the plan and, unless
+// opt.DryRun is set, applies it. The plan is returned even when applying
+// it fails part way through.
+func Sync(ctx context.Context, client *kong.Client, desired *Config, opt *SyncOptions) (*Plan, error) {
+	if opt == nil {
+		opt = new(SyncOptions)
+	}
+
+	current, err := Fetch(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := Diff(current, desired)
+	if opt.Out != nil {
+		if err := plan.Print(opt.Out); err != nil {
+			return plan, err
+		}
+	}
+
+	if opt.DryRun {
+		return plan, nil
+	}
+
+	return plan, plan.Apply(ctx, client)
+}
+
+// Apply carries out the changes of p, in order, against the Kong instance
+// behind client. It stops at the first change which fails.
+func (p *Plan) Apply(ctx context.Context, client *kong.Client) error {
+	a := &applier{client: client, consumers: make(map[string]string)}
+	for _, c := range p.Changes {
+		if err := a.apply(ctx, c); err != nil {
+			return fmt.Errorf("%v: %w", c, err)
+		}
+	}
+	return nil
+}
+
+// applier carries out changes, remembering the ids of the consumers
+// plugins are applied to.
+type applier struct {
+	client    *kong.Client
+	consumers map[string]string
+}
+
+func (a *applier) apply(ctx context.Context, c *Change) error {
+	switch c.Kind {
+	case KindApi:
+		return a.api(ctx, c)
+	case KindConsumer:
+		return a.consumer(ctx, c)
+	case KindACL:
+		return a.acl(ctx, c)
+	case KindJWT:
+		return a.jwt(ctx, c)
+	case KindKeyAuth:
+		return a.keyAuth(ctx, c)
+	case KindPlugin:
+		return a.plugin(ctx, c)
+	case KindUpstream:
+		return a.upstream(ctx, c)
+	case KindTarget:
+		return a.target(ctx, c)
+	}
+	return fmt.Errorf("unknown kind %q", c.Kind)
+}
+
+func (a *applier) api(ctx context.Context, c *Change) error {
+	var err error
+	switch c.Op {
+	case Create:
+		// Only send the fields c.New sets, so Kong applies its defaults
+		// to the others rather than the zero values of kong.ApiRequest
+		err = a.do(ctx, http.MethodPost, "apis", fields(c.New.(*Api).Api))
+	case Update:
+		// The api fields are not omitempty, so start from the current
+		// values to leave the ones c.New does not set untouched
+		api := new(kong.ApiRequest)
+		if err := merge(api, c.Old.(*Api).Api, c.New.(*Api).Api); err != nil {
+			return err
+		}
+		_, err = a.client.Apis.PatchWithContext(ctx, api)
+	case Delete:
+		_, err = a.client.Apis.DeleteWithContext(ctx, c.Name)
+	}
+	return err
+}
+
+func (a *applier) consumer(ctx context.Context, c *Change) error {
+	var err error
+	switch c.Op {
+	case Create:
+		consumer := c.New.(*Consumer).Consumer
+		_, err = a.client.Consumers.PostWithContext(ctx, &consumer)
+	case Update:
+		consumer := c.New.(*Consumer).Consumer
+		consumer.ID = c.Old.(*Consumer).ID
+		_, err = a.client.Consumers.PatchWithContext(ctx, &consumer)
+	case Delete:
+		_, err = a.client.Consumers.DeleteWithContext(ctx, c.Old.(*Consumer).ID)
+	}
+	return err
+}
+
+func (a *applier) acl(ctx context.Context, c *Change) error {
+	var err error
+	switch c.Op {
+	case Create:
+		_, err = a.client.Consumers.Plugins.ACL.PostWithContext(ctx, c.Parent, &kong.ConsumerACLConfig{Group: c.Name})
+	case Delete:
+		_, err = a.client.Consumers.Plugins.ACL.DeleteWithContext(ctx, c.Parent, c.Old.(*kong.ConsumerACLConfig).ID)
+	}
+	return err
+}
+
+func (a *applier) jwt(ctx context.Context, c *Change) error {
+	var err error
+	switch c.Op {
+	case Create:
+		jwt := *c.New.(*kong.ConsumerJWTConfig)
+		jwt.ID = ""
+		_, _, err = a.client.Consumers.Plugins.JWT.PostWithContext(ctx, c.Parent, &jwt)
+	case Update:
+		jwt := *c.New.(*kong.ConsumerJWTConfig)
+		jwt.ID = c.Old.(*kong.ConsumerJWTConfig).ID
+		_, _, err = a.client.Consumers.Plugins.JWT.PatchWithContext(ctx, c.Parent, &jwt)
+	case Delete:
+		_, err = a.client.Consumers.Plugins.JWT.DeleteWithContext(ctx, c.Parent, c.Old.(*kong.ConsumerJWTConfig).ID)
+	}
+	return err
+}
+
+func (a *applier) keyAuth(ctx context.Context, c *Change) error {
+	var err error
+	switch c.Op {
+	case Create:
+		_, _, err = a.client.Consumers.Plugins.KeyAuth.PostWithContext(ctx, c.Parent, &kong.ConsumerKeyAuthConfig{Key: c.Name})
+	case Delete:
+		_, err = a.client.Consumers.Plugins.KeyAuth.DeleteWithContext(ctx, c.Parent, c.Old.(*kong.ConsumerKeyAuthConfig).ID)
+	}
+	return err
+}
+
+func (a *applier) plugin(ctx context.Context, c *Change) error {
+	api := c.Parent
+
+	switch c.Op {
+	case Create, Update:
+		want := c.New.(*Plugin)
+		plugin := &kong.Plugin{Name: want.Name, Enabled: want.Enabled, Config: want.Config}
+		if want.Consumer != "" {
+			id, err := a.consumerID(ctx, want.Consumer)
+			if err != nil {
+				return err
+			}
+			plugin.ConsumerID = id
+		}
+
+		if c.Op == Create {
+			var err error
+			if api != "" {
+				_, err = a.client.Apis.Plugins.PostWithContext(ctx, api, plugin)
+			} else {
+				_, err = a.client.Plugins.PostWithContext(ctx, plugin)
+			}
+			return err
+		}
+
+		plugin.ID = c.Old.(*Plugin).ID
+		if api != "" {
+			_, err := a.client.Apis.Plugins.PatchWithContext(ctx, api, plugin)
+			return err
+		}
+		return a.do(ctx, http.MethodPatch, "plugins/"+plugin.ID, plugin)
+	case Delete:
+		id := c.Old.(*Plugin).ID
+		if api != "" {
+			_, err := a.client.Plugins.DeleteWithContext(ctx, api, id)
+			return err
+		}
+		return a.do(ctx, http.MethodDelete, "plugins/"+id, nil)
+	}
+	return nil
+}
+
+// consumerID looks up the id of the consumer with the given username.
+func (a *applier) consumerID(ctx context.Context, username string) (string, error) {
+	if id, ok := a.consumers[username]; ok {
+		return id, nil
+	}
+
+	consumer, _, err := a.client.Consumers.GetWithContext(ctx, username)
+	if err != nil {
+		return "", fmt.Errorf("looking up consumer %q: %w", username, err)
+	}
+	a.consumers[username] = consumer.ID
+
+	return consumer.ID, nil
+}
+
+func (a *applier) upstream(ctx context.Context, c *Change) error {
+	var err error
+	switch c.Op {
+	case Create:
+		upstream := c.New.(*Upstream).Upstream
+		_, err = a.client.Upstreams.PostWithContext(ctx, &upstream)
+	case Update:
+		upstream := c.New.(*Upstream).Upstream
+		upstream.ID = c.Old.(*Upstream).ID
+		_, err = a.client.Upstreams.PatchWithContext(ctx, &upstream)
+	case Delete:
+		_, err = a.client.Upstreams.DeleteWithContext(ctx, c.Name)
+	}
+	return err
+}
+
+func (a *applier) target(ctx context.Context, c *Change) error {
+	var err error
+	switch c.Op {
+	case Create:
+		target := *c.New.(*kong.Target)
+		_, err = a.client.Targets.PostWithContext(ctx, c.Parent, &target)
+	case Update:
+		_, _, err = a.client.Targets.SetWeightWithContext(ctx, c.Parent, c.Name, c.New.(*kong.Target).Weight)
+	case Delete:
+		_, err = a.client.Targets.DeleteWithContext(ctx, c.Parent, c.Name)
+	}
+	return err
+}
+
+// do sends a request the services of kong.Client have no method for, such
+// as changing a plugin which is not bound to an api, or one with a body
+// they cannot express.
+func (a *applier) do(ctx context.Context, method, path string, body interface{}) error {
+	req, err := a.client.NewRequestWithContext(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.client.Do(req, nil)
+	return err
+}
+
+// merge stores into dst the fields of current overlaid with the fields
+// want sets, leaving out the id and creation time.
+func merge(dst, current, want interface{}) error {
+	m, _ := normalize(current).(map[string]interface{})
+	if m == nil {
+		m = make(map[string]interface{})
+	}
+	for k, v := range fields(want) {
+		m[k] = v
+	}
+	delete(m, "id")
+	delete(m, "created_at")
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dst)
+}
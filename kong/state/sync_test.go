@@ -0,0 +1,169 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nccurry/go-kong/kong"
+)
+
+// recorder records the requests it receives as "METHOD /path body".
+type recorder struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+func (rec *recorder) handler(status int, response string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		rec.mu.Lock()
+		rec.requests = append(rec.requests, strings.TrimSpace(fmt.Sprintf("%v %v %s", r.Method, r.URL.Path, bytes.TrimSpace(body))))
+		rec.mu.Unlock()
+
+		w.WriteHeader(status)
+		fmt.Fprint(w, response)
+	}
+}
+
+func TestPlan_Apply(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	rec := new(recorder)
+	mux.HandleFunc("/upstreams", rec.handler(http.StatusCreated, `{}`))
+	mux.HandleFunc("/upstreams/service.v1/targets", rec.handler(http.StatusCreated, `{}`))
+	mux.HandleFunc("/apis", rec.handler(http.StatusCreated, `{}`))
+	mux.HandleFunc("/apis/old", rec.handler(http.StatusNoContent, ``))
+	mux.HandleFunc("/consumers", rec.handler(http.StatusCreated, `{}`))
+	mux.HandleFunc("/consumers/bob", rec.handler(http.StatusOK, `{"id":"c1","username":"bob"}`))
+	mux.HandleFunc("/consumers/bob/acls", rec.handler(http.StatusCreated, `{}`))
+	mux.HandleFunc("/consumers/bob/jwt/j1", rec.handler(http.StatusOK, `{}`))
+	mux.HandleFunc("/apis/mockbin/plugins", rec.handler(http.StatusCreated, `{}`))
+	mux.HandleFunc("/plugins/p2", rec.handler(http.StatusOK, `{}`))
+
+	plan := &Plan{Changes: []*Change{
+		{Op: Delete, Kind: KindApi, Name: "old", Old: &Api{}},
+		{Op: Create, Kind: KindUpstream, Name: "service.v1", New: &Upstream{Upstream: kong.Upstream{Name: "service.v1"}}},
+		{Op: Create, Kind: KindTarget, Name: "10.0.0.1:80", Parent: "service.v1", New: &kong.Target{Target: "10.0.0.1:80"}},
+		{Op: Update, Kind: KindTarget, Name: "10.0.0.2:80", Parent: "service.v1", New: &kong.Target{Target: "10.0.0.2:80", Weight: 0}},
+		{Op: Create, Kind: KindApi, Name: "mockbin", New: &Api{Api: kong.Api{Name: "mockbin", UpstreamURL: "http://mockbin.org", Retries: 3}}},
+		{Op: Create, Kind: KindConsumer, Name: "bob", New: &Consumer{Consumer: kong.Consumer{Username: "bob"}}},
+		{Op: Create, Kind: KindACL, Name: "admins", Parent: "bob", New: &kong.ConsumerACLConfig{Group: "admins"}},
+		{Op: Update, Kind: KindJWT, Name: "iss", Parent: "bob", Old: &kong.ConsumerJWTConfig{ID: "j1", Key: "iss"}, New: &kong.ConsumerJWTConfig{Key: "iss", Secret: "n3w"}},
+		{Op: Create, Kind: KindPlugin, Name: "acl", Parent: "mockbin", New: &Plugin{Name: "acl", Consumer: "bob"}},
+		{Op: Update, Kind: KindPlugin, Name: "cors", Old: &Plugin{ID: "p2", Name: "cors"}, New: &Plugin{Name: "cors", Enabled: kong.Bool(false)}},
+	}}
+
+	if err := plan.Apply(context.Background(), client); err != nil {
+		t.Fatalf("Plan.Apply returned error: %v", err)
+	}
+
+	want := []string{
+		"DELETE /apis/old",
+		`POST /upstreams {"name":"service.v1"}`,
+		`POST /upstreams/service.v1/targets {"target":"10.0.0.1:80"}`,
+		`POST /upstreams/service.v1/targets {"target":"10.0.0.2:80","weight":0}`,
+		`POST /apis {"name":"mockbin","retries":3,"upstream_url":"http://mockbin.org"}`,
+		`POST /consumers {"username":"bob"}`,
+		`POST /consumers/bob/acls {"group":"admins"}`,
+		`PATCH /consumers/bob/jwt/j1 {"key":"iss","secret":"n3w","id":"j1"}`,
+		"GET /consumers/bob",
+		`POST /apis/mockbin/plugins {"name":"acl","consumer_id":"c1"}`,
+		`PATCH /plugins/p2 {"id":"p2","name":"cors","enabled":false}`,
+	}
+	if !reflect.DeepEqual(rec.requests, want) {
+		t.Errorf("Plan.Apply sent %q, want %q", rec.requests, want)
+	}
+}
+
+func TestPlan_Apply_updateApi(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	var got map[string]interface{}
+	mux.HandleFunc("/apis/mockbin", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Request method: %v, want PATCH", r.Method)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		fmt.Fprint(w, `{}`)
+	})
+
+	plan := &Plan{Changes: []*Change{{
+		Op:   Update,
+		Kind: KindApi,
+		Name: "mockbin",
+		Old:  &Api{Api: kong.Api{ID: "a1", CreatedAt: 1, Name: "mockbin", UpstreamURL: "http://mockbin.org", Retries: 5, StripUri: true}},
+		New:  &Api{Api: kong.Api{Name: "mockbin", UpstreamURL: "http://mockbin.com"}},
+	}}}
+
+	if err := plan.Apply(context.Background(), client); err != nil {
+		t.Fatalf("Plan.Apply returned error: %v", err)
+	}
+
+	// Fields the desired api leaves unset keep their current values
+	if got["upstream_url"] != "http://mockbin.com" || got["retries"] != float64(5) || got["strip_uri"] != true {
+		t.Errorf("Plan.Apply sent %v, want current values overlaid with the desired ones", got)
+	}
+	if _, ok := got["id"]; ok {
+		t.Errorf("Plan.Apply sent id in %v", got)
+	}
+}
+
+func TestPlan_Apply_error(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"username":"already exists with value 'bob'"}`)
+	})
+
+	plan := &Plan{Changes: []*Change{
+		{Op: Create, Kind: KindConsumer, Name: "bob", New: &Consumer{Consumer: kong.Consumer{Username: "bob"}}},
+		{Op: Create, Kind: KindConsumer, Name: "eve", New: &Consumer{Consumer: kong.Consumer{Username: "eve"}}},
+	}}
+
+	err := plan.Apply(context.Background(), client)
+	if err == nil || !strings.HasPrefix(err.Error(), "create consumer bob: ") {
+		t.Errorf("Plan.Apply returned error %v, want create consumer bob error", err)
+	}
+}
+
+func TestSync_dryRun(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	for _, path := range []string{"/apis", "/consumers", "/plugins", "/upstreams"} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				t.Errorf("Sync sent %v %v during a dry run", r.Method, r.URL.Path)
+			}
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+	}
+
+	desired := &Config{Consumers: []*Consumer{{Consumer: kong.Consumer{Username: "bob"}}}}
+
+	var buf bytes.Buffer
+	plan, err := Sync(context.Background(), client, desired, &SyncOptions{DryRun: true, Out: &buf})
+	if err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+
+	if got, want := titles(plan), []string{"create consumer bob"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Sync returned %q, want %q", got, want)
+	}
+	if !strings.HasPrefix(buf.String(), "+ consumer bob\n") {
+		t.Errorf("Sync printed %q, want the plan", buf.String())
+	}
+}
@@ -2,6 +2,7 @@ package kong
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -45,9 +46,14 @@ type Target struct {
 //
 // Equivalent to GET/upstreams/{name or id}/targets/active
 func (s *TargetsService) GetAllActive(upstream string) (*Targets, *http.Response, error) {
+	return s.GetAllActiveWithContext(context.Background(), upstream)
+}
+
+// GetAllActiveWithContext is like GetAllActive but uses ctx for the request.
+func (s *TargetsService) GetAllActiveWithContext(ctx context.Context, upstream string) (*Targets, *http.Response, error) {
 	u := fmt.Sprintf("upstreams/%v/targets/active", upstream)
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -95,9 +101,14 @@ func (s *TargetsService) GetAllActive(upstream string) (*Targets, *http.Response
 //
 // Equivalent to DELETE /upstreams/{name or id}/targets/{target}
 func (s *TargetsService) Delete(upstream string, target string) (*http.Response, error) {
+	return s.DeleteWithContext(context.Background(), upstream, target)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *TargetsService) DeleteWithContext(ctx context.Context, upstream string, target string) (*http.Response, error) {
 	u := fmt.Sprintf("upstreams/%v/targets/%v", upstream, target)
 
-	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -110,11 +121,239 @@ func (s *TargetsService) Delete(upstream string, target string) (*http.Response,
 	return resp, err
 }
 
-// Post creates a new Kong target object.
+// Post creates a new Kong target object. Use Create to get the created
+// object back.
 //
 // Equivalent to POST /upstreams/{name or id}/targets
 func (s *TargetsService) Post(upstream string, target *Target) (*http.Response, error) {
-	req, err := s.client.NewRequest(http.MethodPost, fmt.Sprintf("upstreams/%v/targets", upstream), target)
+	return s.PostWithContext(context.Background(), upstream, target)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *TargetsService) PostWithContext(ctx context.Context, upstream string, target *Target) (*http.Response, error) {
+	_, resp, err := s.CreateWithContext(ctx, upstream, target)
+	return resp, err
+}
+
+// Create is like Post but returns the created Kong target object,
+// including its id and the weight Kong defaulted it to.
+//
+// Equivalent to POST /upstreams/{name or id}/targets
+func (s *TargetsService) Create(upstream string, target *Target) (*Target, *http.Response, error) {
+	return s.CreateWithContext(context.Background(), upstream, target)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *TargetsService) CreateWithContext(ctx context.Context, upstream string, target *Target) (*Target, *http.Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("upstreams/%v/targets", upstream), target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Target)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// TargetsGetAllOptions specifies optional filter parameters to the
+// TargetsService.GetAll method.
+//
+// Additional information about filtering options can be found in
+// the Kong documentation at:
+// https://getkong.org/docs/0.12.x/admin-api/#list-targets
+type TargetsGetAllOptions struct {
+	ID     string `url:"id,omitempty"`     // A filter on the list based on the target id field.
+	Target string `url:"target,omitempty"` // A filter on the list based on the target target field.
+	Weight int    `url:"weight,omitempty"` // A filter on the list based on the target weight field.
+	Size   int    `url:"size,omitempty"`   // A limit on the number of objects to be returned.
+	Offset string `url:"offset,omitempty"` // A cursor used for pagination. offset is an object identifier that defines a place in the list.
+}
+
+// GetAll lists all the target objects attached to the specified upstream,
+// including historical entries which have since been replaced by a
+// newer entry for the same target.
+// This query can be filtered by supplying the TargetsGetAllOptions struct.
+//
+// Equivalent to GET /upstreams/{name or id}/targets?uri=params&from=opt
+func (s *TargetsService) GetAll(upstream string, opt *TargetsGetAllOptions) (*Targets, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), upstream, opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *TargetsService) GetAllWithContext(ctx context.Context, upstream string, opt *TargetsGetAllOptions) (*Targets, *http.Response, error) {
+	u, err := addOptions(fmt.Sprintf("upstreams/%v/targets", upstream), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	targets := new(Targets)
+	resp, err := s.client.Do(req, targets)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return targets, resp, err
+}
+
+// Iterator returns an Iterator over all target objects, historical
+// entries included, attached to the specified upstream.
+func (s *TargetsService) Iterator(upstream string, opt *TargetsGetAllOptions) *Iterator[Target] {
+	return s.IteratorWithContext(context.Background(), upstream, opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *TargetsService) IteratorWithContext(ctx context.Context, upstream string, opt *TargetsGetAllOptions) *Iterator[Target] {
+	o := new(TargetsGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+
+	return newIterator(o.Offset, func(offset string) ([]*Target, string, error) {
+		o.Offset = offset
+		targets, _, err := s.GetAllWithContext(ctx, upstream, o)
+		if err != nil {
+			return nil, "", err
+		}
+		return targets.Data, nextOffset(targets.Next, targets.Offset), nil
+	})
+}
+
+// ListAll returns every target object attached to the specified upstream,
+// following Kong's pagination cursor. If max is greater than zero no more
+// than max objects are returned.
+func (s *TargetsService) ListAll(upstream string, opt *TargetsGetAllOptions, max int) ([]*Target, error) {
+	return s.ListAllWithContext(context.Background(), upstream, opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *TargetsService) ListAllWithContext(ctx context.Context, upstream string, opt *TargetsGetAllOptions, max int) ([]*Target, error) {
+	return s.IteratorWithContext(ctx, upstream, opt).all(max)
+}
+
+// targetWeight is the request body used by SetWeight. Unlike Target it
+// always sends the weight, so that a weight of 0 reaches Kong.
+type targetWeight struct {
+	Target string `json:"target"`
+	Weight int    `json:"weight"`
+}
+
+// SetWeight changes the weight of a target on the specified upstream and
+// returns the new target entry. Kong never updates targets in place, so
+// this adds an entry which supersedes the previous one. A weight of 0
+// takes the target out of the load balancer rotation.
+//
+// Equivalent to POST /upstreams/{name or id}/targets
+func (s *TargetsService) SetWeight(upstream string, target string, weight int) (*Target, *http.Response, error) {
+	return s.SetWeightWithContext(context.Background(), upstream, target, weight)
+}
+
+// SetWeightWithContext is like SetWeight but uses ctx for the request.
+func (s *TargetsService) SetWeightWithContext(ctx context.Context, upstream string, target string, weight int) (*Target, *http.Response, error) {
+	u := fmt.Sprintf("upstreams/%v/targets", upstream)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, u, &targetWeight{Target: target, Weight: weight})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(Target)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// UpstreamHealth represents the object returned from Kong when querying
+// for the health of the targets of an upstream, as seen by the Kong node
+// identified by NodeID.
+type UpstreamHealth struct {
+	NodeID string          `json:"node_id,omitempty"`
+	Data   []*TargetHealth `json:"data,omitempty"`
+	Total  int             `json:"total,omitempty"`
+	Next   string          `json:"next,omitempty"`
+	Offset string          `json:"offset,omitempty"`
+}
+
+// TargetHealth represents a single target together with its health.
+//
+// Health is one of "HEALTHY", "UNHEALTHY", "DNS_ERROR" or
+// "HEALTHCHECKS_OFF".
+type TargetHealth struct {
+	Target     string `json:"target"`
+	ID         string `json:"id,omitempty"`
+	CreatedAt  int64  `json:"created_at,omitempty"`
+	Weight     int    `json:"weight,omitempty"`
+	UpstreamID string `json:"upstream_id,omitempty"`
+	Health     string `json:"health,omitempty"`
+}
+
+// GetHealth queries for the health of every target attached to the
+// specified upstream.
+//
+// Equivalent to GET /upstreams/{name or id}/health
+func (s *TargetsService) GetHealth(upstream string) (*UpstreamHealth, *http.Response, error) {
+	return s.GetHealthWithContext(context.Background(), upstream)
+}
+
+// GetHealthWithContext is like GetHealth but uses ctx for the request.
+func (s *TargetsService) GetHealthWithContext(ctx context.Context, upstream string) (*UpstreamHealth, *http.Response, error) {
+	u := fmt.Sprintf("upstreams/%v/health", upstream)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(UpstreamHealth)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// SetHealthy marks a target of the specified upstream as healthy,
+// returning it to the load balancer rotation on the Kong cluster.
+//
+// Equivalent to POST /upstreams/{name or id}/targets/{target}/healthy
+func (s *TargetsService) SetHealthy(upstream string, target string) (*http.Response, error) {
+	return s.SetHealthyWithContext(context.Background(), upstream, target)
+}
+
+// SetHealthyWithContext is like SetHealthy but uses ctx for the request.
+func (s *TargetsService) SetHealthyWithContext(ctx context.Context, upstream string, target string) (*http.Response, error) {
+	return s.setHealth(ctx, upstream, target, "healthy")
+}
+
+// SetUnhealthy marks a target of the specified upstream as unhealthy,
+// taking it out of the load balancer rotation on the Kong cluster.
+//
+// Equivalent to POST /upstreams/{name or id}/targets/{target}/unhealthy
+func (s *TargetsService) SetUnhealthy(upstream string, target string) (*http.Response, error) {
+	return s.SetUnhealthyWithContext(context.Background(), upstream, target)
+}
+
+// SetUnhealthyWithContext is like SetUnhealthy but uses ctx for the request.
+func (s *TargetsService) SetUnhealthyWithContext(ctx context.Context, upstream string, target string) (*http.Response, error) {
+	return s.setHealth(ctx, upstream, target, "unhealthy")
+}
+
+func (s *TargetsService) setHealth(ctx context.Context, upstream string, target string, health string) (*http.Response, error) {
+	u := fmt.Sprintf("upstreams/%v/targets/%v/%v", upstream, target, health)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -86,6 +86,28 @@ func TestTargets_Post_badStatusCode(t *testing.T) {
 	}
 }
 
+func TestTargets_Create(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/upstreams/u/targets", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"target":"10.0.0.1:80"}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"i","target":"10.0.0.1:80","weight":100,"upstream_id":"u1"}`)
+	})
+
+	target, _, err := client.Targets.Create("u", &Target{Target: "10.0.0.1:80"})
+	if err != nil {
+		t.Errorf("Targets.Create returned error: %v", err)
+	}
+
+	want := &Target{ID: "i", Target: "10.0.0.1:80", Weight: 100, UpstreamID: "u1"}
+	if !reflect.DeepEqual(target, want) {
+		t.Errorf("Targets.Create returned %+v, want %+v", target, want)
+	}
+}
+
 func TestTargets_GetAllActive(t *testing.T) {
 	stubSetup()
 	defer stubTeardown()
@@ -143,6 +165,158 @@ func TestTargets_GetAllActive_badStatusCode(t *testing.T) {
 	}
 }
 
+func TestTargets_GetAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	v := &Targets{Total: 2, Offset: "o2", Data: []*Target{sampleTarget(), {Target: "service:80", Weight: 0}}}
+
+	mux.HandleFunc(fmt.Sprintf("/upstreams/%s/targets", upstreamName), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"target": "service:80", "offset": "o"})
+		json.NewEncoder(w).Encode(v)
+	})
+
+	targets, _, err := client.Targets.GetAll(upstreamName, &TargetsGetAllOptions{Target: "service:80", Offset: "o"})
+	if err != nil {
+		t.Errorf("Targets.GetAll returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(targets, v) {
+		t.Errorf("Targets.GetAll returned %+v, want %+v", targets, v)
+	}
+}
+
+func TestTargets_GetAll_badStatusCode(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc(fmt.Sprintf("/upstreams/%s/targets", upstreamName), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		fmt.Fprint(w, `{"message":"Not found"}`)
+	})
+
+	_, _, err := client.Targets.GetAll(upstreamName, nil)
+	if err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestTargets_ListAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc(fmt.Sprintf("/upstreams/%s/targets", upstreamName), func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprint(w, `{"data":[{"id":"1","target":"a:80"}],"total":2,"offset":"o"}`)
+		case "o":
+			fmt.Fprint(w, `{"data":[{"id":"2","target":"b:80"}],"total":2}`)
+		}
+	})
+
+	targets, err := client.Targets.ListAll(upstreamName, nil, 0)
+	if err != nil {
+		t.Errorf("Targets.ListAll returned error: %v", err)
+	}
+
+	want := []*Target{{ID: "1", Target: "a:80"}, {ID: "2", Target: "b:80"}}
+	if !reflect.DeepEqual(targets, want) {
+		t.Errorf("Targets.ListAll returned %+v, want %+v", targets, want)
+	}
+}
+
+func TestTargets_SetWeight(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc(fmt.Sprintf("/upstreams/%s/targets", upstreamName), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"target":"service:80","weight":0}`+"\n")
+		w.WriteHeader(201)
+		fmt.Fprint(w, `{"id":"i","target":"service:80","weight":0}`)
+	})
+
+	target, _, err := client.Targets.SetWeight(upstreamName, "service:80", 0)
+	if err != nil {
+		t.Errorf("Targets.SetWeight returned error: %v", err)
+	}
+
+	want := &Target{ID: "i", Target: "service:80"}
+	if !reflect.DeepEqual(target, want) {
+		t.Errorf("Targets.SetWeight returned %+v, want %+v", target, want)
+	}
+}
+
+func TestTargets_GetHealth(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc(fmt.Sprintf("/upstreams/%s/health", upstreamName), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"node_id":"n","total":1,"data":[{"id":"i","target":"service:80","weight":100,"health":"HEALTHY"}]}`)
+	})
+
+	health, _, err := client.Targets.GetHealth(upstreamName)
+	if err != nil {
+		t.Errorf("Targets.GetHealth returned error: %v", err)
+	}
+
+	want := &UpstreamHealth{
+		NodeID: "n",
+		Total:  1,
+		Data:   []*TargetHealth{{ID: "i", Target: "service:80", Weight: 100, Health: "HEALTHY"}},
+	}
+	if !reflect.DeepEqual(health, want) {
+		t.Errorf("Targets.GetHealth returned %+v, want %+v", health, want)
+	}
+}
+
+func TestTargets_SetHealthy(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc(fmt.Sprintf("/upstreams/%s/targets/service:80/healthy", upstreamName), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.WriteHeader(204)
+	})
+
+	_, err := client.Targets.SetHealthy(upstreamName, "service:80")
+	if err != nil {
+		t.Errorf("Targets.SetHealthy returned error: %v", err)
+	}
+}
+
+func TestTargets_SetUnhealthy(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc(fmt.Sprintf("/upstreams/%s/targets/service:80/unhealthy", upstreamName), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.WriteHeader(204)
+	})
+
+	_, err := client.Targets.SetUnhealthy(upstreamName, "service:80")
+	if err != nil {
+		t.Errorf("Targets.SetUnhealthy returned error: %v", err)
+	}
+}
+
+func TestTargets_SetUnhealthy_badStatusCode(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc(fmt.Sprintf("/upstreams/%s/targets/service:80/unhealthy", upstreamName), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+		fmt.Fprint(w, `{"message":"no healthchecks configured"}`)
+	})
+
+	_, err := client.Targets.SetUnhealthy(upstreamName, "service:80")
+	if err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
 func sampleTarget() *Target {
 	return &Target{
 		Target: "service:80",
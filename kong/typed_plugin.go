@@ -0,0 +1,206 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// TypedPlugin is a Kong plugin object whose config is held in the typed
+// config struct C rather than the generic map of Plugin, i.e.
+// TypedPlugin[CorsConfig].
+//
+// Config is converted through encoding/json, so C may use nested structs,
+// bools, floats, maps and pointer fields freely, as long as its json tags
+// match the plugin's schema. Fields of the stored config C has no field
+// for are dropped when decoding.
+type TypedPlugin[C any] struct {
+	ID         string `json:"id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	CreatedAt  int    `json:"created_at,omitempty"`
+	Enabled    *bool  `json:"enabled,omitempty"`
+	ApiID      string `json:"api_id,omitempty"`
+	ServiceID  string `json:"service_id,omitempty"`
+	RouteID    string `json:"route_id,omitempty"`
+	ConsumerID string `json:"consumer_id,omitempty"`
+	Config     C      `json:"config"`
+}
+
+// Encode converts p to the generic Plugin, i.e. for use with the methods
+// of PluginsService.
+func (p *TypedPlugin[C]) Encode() (*Plugin, error) {
+	b, err := json.Marshal(p.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	var config map[string]interface{}
+	if err := json.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("config of plugin %q is not a JSON object: %w", p.Name, err)
+	}
+
+	return &Plugin{
+		ID:         p.ID,
+		Name:       p.Name,
+		CreatedAt:  p.CreatedAt,
+		Enabled:    p.Enabled,
+		ApiID:      p.ApiID,
+		ServiceID:  p.ServiceID,
+		RouteID:    p.RouteID,
+		ConsumerID: p.ConsumerID,
+		Config:     config,
+	}, nil
+}
+
+// Decode sets p from the generic Plugin, i.e. as returned by
+// PluginsService.Get.
+func (p *TypedPlugin[C]) Decode(plugin *Plugin) error {
+	b, err := json.Marshal(plugin.Config)
+	if err != nil {
+		return err
+	}
+
+	var config C
+	if err := json.Unmarshal(b, &config); err != nil {
+		return fmt.Errorf("decoding config of plugin %q: %w", plugin.Name, err)
+	}
+
+	*p = TypedPlugin[C]{
+		ID:         plugin.ID,
+		Name:       plugin.Name,
+		CreatedAt:  plugin.CreatedAt,
+		Enabled:    plugin.Enabled,
+		ApiID:      plugin.ApiID,
+		ServiceID:  plugin.ServiceID,
+		RouteID:    plugin.RouteID,
+		ConsumerID: plugin.ConsumerID,
+		Config:     config,
+	}
+	return nil
+}
+
+// Go methods cannot take type parameters, so the typed counterparts of
+// the PluginsService methods are functions taking the service.
+
+// GetTypedPlugin queries for a single Kong plugin object by id, decoding
+// its config into C.
+//
+// Equivalent to GET /plugins/{id}
+func GetTypedPlugin[C any](s *PluginsService, id string) (*TypedPlugin[C], *http.Response, error) {
+	return GetTypedPluginWithContext[C](context.Background(), s, id)
+}
+
+// GetTypedPluginWithContext is like GetTypedPlugin but uses ctx for the
+// request.
+func GetTypedPluginWithContext[C any](ctx context.Context, s *PluginsService, id string) (*TypedPlugin[C], *http.Response, error) {
+	u := fmt.Sprintf("plugins/%v", id)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plugin := new(TypedPlugin[C])
+	resp, err := s.client.Do(req, plugin)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return plugin, resp, err
+}
+
+// PostTypedPlugin creates a new Kong plugin object from its typed form and
+// returns it as stored by Kong, including the defaults Kong filled in.
+//
+// Equivalent to POST /plugins
+func PostTypedPlugin[C any](s *PluginsService, plugin *TypedPlugin[C]) (*TypedPlugin[C], *http.Response, error) {
+	return PostTypedPluginWithContext(context.Background(), s, plugin)
+}
+
+// PostTypedPluginWithContext is like PostTypedPlugin but uses ctx for the
+// request.
+func PostTypedPluginWithContext[C any](ctx context.Context, s *PluginsService, plugin *TypedPlugin[C]) (*TypedPlugin[C], *http.Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, "plugins", plugin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(TypedPlugin[C])
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// PatchTypedPlugin updates an existing Kong plugin object from its typed
+// form and returns it as stored by Kong. plugin.ID must be specified.
+//
+// Fields left at their zero value in a config struct with omitempty tags
+// are not sent, so they keep their current value.
+//
+// Equivalent to PATCH /plugins/{id}
+func PatchTypedPlugin[C any](s *PluginsService, plugin *TypedPlugin[C]) (*TypedPlugin[C], *http.Response, error) {
+	return PatchTypedPluginWithContext(context.Background(), s, plugin)
+}
+
+// PatchTypedPluginWithContext is like PatchTypedPlugin but uses ctx for the
+// request.
+func PatchTypedPluginWithContext[C any](ctx context.Context, s *PluginsService, plugin *TypedPlugin[C]) (*TypedPlugin[C], *http.Response, error) {
+	if plugin.ID == "" {
+		return nil, nil, errors.New("plugin.ID must be specified")
+	}
+
+	u := fmt.Sprintf("plugins/%v", plugin.ID)
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, u, plugin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uResp := new(TypedPlugin[C])
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return uResp, resp, err
+}
+
+// ListAllTypedPlugins follows Kong's pagination cursor and returns every
+// plugin object named name matching opt, decoding their configs into C.
+// If max is greater than zero no more than max objects are returned.
+//
+// opt.Name is overridden by name, so plugins of another kind are never
+// decoded into C.
+func ListAllTypedPlugins[C any](s *PluginsService, name string, opt *PluginsGetAllOptions, max int) ([]*TypedPlugin[C], error) {
+	return ListAllTypedPluginsWithContext[C](context.Background(), s, name, opt, max)
+}
+
+// ListAllTypedPluginsWithContext is like ListAllTypedPlugins but uses ctx
+// for every page request.
+func ListAllTypedPluginsWithContext[C any](ctx context.Context, s *PluginsService, name string, opt *PluginsGetAllOptions, max int) ([]*TypedPlugin[C], error) {
+	o := new(PluginsGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+	o.Name = name
+
+	plugins, err := s.ListAllWithContext(ctx, o, max)
+	if err != nil {
+		return nil, err
+	}
+
+	typed := make([]*TypedPlugin[C], len(plugins))
+	for i, plugin := range plugins {
+		typed[i] = new(TypedPlugin[C])
+		if err := typed[i].Decode(plugin); err != nil {
+			return nil, err
+		}
+	}
+
+	return typed, nil
+}
@@ -0,0 +1,192 @@
+package kong
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestTypedPlugin_Encode(t *testing.T) {
+	plugin := &TypedPlugin[ResponseRateLimitingConfig]{
+		Name:  "response-ratelimiting",
+		ApiID: "a1",
+		Config: ResponseRateLimitingConfig{
+			Limits:        map[string]*ResponseRateLimits{"video": {Minute: 10}},
+			FaultTolerant: Bool(false),
+		},
+	}
+
+	got, err := plugin.Encode()
+	if err != nil {
+		t.Fatalf("TypedPlugin.Encode returned error: %v", err)
+	}
+
+	want := &Plugin{
+		Name:  "response-ratelimiting",
+		ApiID: "a1",
+		Config: map[string]interface{}{
+			"limits":         map[string]interface{}{"video": map[string]interface{}{"minute": float64(10)}},
+			"fault_tolerant": false,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TypedPlugin.Encode returned %+v, want %+v", got, want)
+	}
+}
+
+func TestTypedPlugin_Encode_notObject(t *testing.T) {
+	plugin := &TypedPlugin[[]string]{Name: "cors", Config: []string{"a"}}
+	if _, err := plugin.Encode(); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestTypedPlugin_Decode(t *testing.T) {
+	plugin := &Plugin{
+		ID:      "p1",
+		Name:    "statsd",
+		Enabled: Bool(true),
+		Config: map[string]interface{}{
+			"host": "127.0.0.1",
+			"port": float64(8125),
+			"metrics": []interface{}{
+				map[string]interface{}{"name": "request_count", "stat_type": "counter", "sample_rate": 0.5},
+			},
+			"unknown": "dropped",
+		},
+	}
+
+	got := new(TypedPlugin[StatsdConfig])
+	if err := got.Decode(plugin); err != nil {
+		t.Fatalf("TypedPlugin.Decode returned error: %v", err)
+	}
+
+	want := &TypedPlugin[StatsdConfig]{
+		ID:      "p1",
+		Name:    "statsd",
+		Enabled: Bool(true),
+		Config: StatsdConfig{
+			Host:    "127.0.0.1",
+			Port:    8125,
+			Metrics: []*StatsdMetric{{Name: "request_count", StatType: "counter", SampleRate: 0.5}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TypedPlugin.Decode returned %+v, want %+v", got, want)
+	}
+
+	plugin.Config["port"] = "not a port"
+	if err := got.Decode(plugin); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestGetTypedPlugin(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/plugins/p1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"p1","name":"cors","config":{"origins":["*"],"credentials":false,"max_age":3600}}`)
+	})
+
+	plugin, _, err := GetTypedPlugin[CorsConfig](client.Plugins, "p1")
+	if err != nil {
+		t.Errorf("GetTypedPlugin returned error: %v", err)
+	}
+
+	want := &TypedPlugin[CorsConfig]{
+		ID:     "p1",
+		Name:   "cors",
+		Config: CorsConfig{Origins: []string{"*"}, Credentials: Bool(false), MaxAge: 3600},
+	}
+	if !reflect.DeepEqual(plugin, want) {
+		t.Errorf("GetTypedPlugin returned %+v, want %+v", plugin, want)
+	}
+}
+
+func TestPostTypedPlugin(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/plugins", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"name":"request-transformer","config":{"add":{"headers":["x-version:2"]}}}`+"\n")
+		fmt.Fprint(w, `{"id":"p1","name":"request-transformer","config":{"add":{"headers":["x-version:2"],"body":[]}}}`)
+	})
+
+	input := &TypedPlugin[RequestTransformerConfig]{
+		Name:   "request-transformer",
+		Config: RequestTransformerConfig{Add: &RequestTransformerFields{Headers: []string{"x-version:2"}}},
+	}
+	plugin, _, err := PostTypedPlugin(client.Plugins, input)
+	if err != nil {
+		t.Errorf("PostTypedPlugin returned error: %v", err)
+	}
+
+	want := &TypedPlugin[RequestTransformerConfig]{
+		ID:     "p1",
+		Name:   "request-transformer",
+		Config: RequestTransformerConfig{Add: &RequestTransformerFields{Headers: []string{"x-version:2"}, Body: []string{}}},
+	}
+	if !reflect.DeepEqual(plugin, want) {
+		t.Errorf("PostTypedPlugin returned %+v, want %+v", plugin, want)
+	}
+}
+
+func TestPatchTypedPlugin(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/plugins/p1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"id":"p1","config":{"max_age":60}}`+"\n")
+		fmt.Fprint(w, `{"id":"p1","name":"cors","config":{"origins":["*"],"max_age":60}}`)
+	})
+
+	plugin, _, err := PatchTypedPlugin(client.Plugins, &TypedPlugin[CorsConfig]{ID: "p1", Config: CorsConfig{MaxAge: 60}})
+	if err != nil {
+		t.Errorf("PatchTypedPlugin returned error: %v", err)
+	}
+
+	want := &TypedPlugin[CorsConfig]{ID: "p1", Name: "cors", Config: CorsConfig{Origins: []string{"*"}, MaxAge: 60}}
+	if !reflect.DeepEqual(plugin, want) {
+		t.Errorf("PatchTypedPlugin returned %+v, want %+v", plugin, want)
+	}
+
+	if _, _, err := PatchTypedPlugin(client.Plugins, &TypedPlugin[CorsConfig]{}); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestListAllTypedPlugins(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/plugins", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.URL.Query().Get("name"); got != "ip-restriction" {
+			t.Errorf("Request name = %q, want ip-restriction", got)
+		}
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprint(w, `{"data":[{"id":"1","config":{"whitelist":["10.0.0.1"]}}],"total":2,"offset":"o"}`)
+		case "o":
+			fmt.Fprint(w, `{"data":[{"id":"2","config":{"blacklist":["10.0.0.2"]}}],"total":2}`)
+		}
+	})
+
+	plugins, err := ListAllTypedPlugins[IPRestrictionConfig](client.Plugins, "ip-restriction", &PluginsGetAllOptions{Name: "cors"}, 0)
+	if err != nil {
+		t.Errorf("ListAllTypedPlugins returned error: %v", err)
+	}
+
+	want := []*TypedPlugin[IPRestrictionConfig]{
+		{ID: "1", Config: IPRestrictionConfig{Whitelist: []string{"10.0.0.1"}}},
+		{ID: "2", Config: IPRestrictionConfig{Blacklist: []string{"10.0.0.2"}}},
+	}
+	if !reflect.DeepEqual(plugins, want) {
+		t.Errorf("ListAllTypedPlugins returned %+v, want %+v", plugins, want)
+	}
+}
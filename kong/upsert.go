@@ -0,0 +1,223 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// UpsertResult reports what an Upsert method did.
+type UpsertResult int
+
+const (
+	Unchanged UpsertResult = iota // The entity already matched and was left alone.
+	Created                       // No such entity existed, so it was created.
+	Updated                       // The entity existed and was updated.
+)
+
+func (r UpsertResult) String() string {
+	switch r {
+	case Unchanged:
+		return "unchanged"
+	case Created:
+		return "created"
+	case Updated:
+		return "updated"
+	default:
+		return fmt.Sprintf("UpsertResult(%d)", int(r))
+	}
+}
+
+// upsert creates or updates an entity of the collection at path so it
+// matches desired, find returning the existing entity or nil when there
+// is none.
+//
+// An existing entity is left alone when every field set on desired
+// already holds the same value, nested objects such as plugin configs
+// being compared field by field. Otherwise the fields of desired are laid
+// over the existing entity, so the ones desired leaves unset keep their
+// values, and the result is written with a PUT on the collection. The
+// write falls back to a POST on the collection or a PATCH of the entity
+// when Kong does not accept PUT there.
+func upsert[T any](ctx context.Context, s *service, path string, desired interface{}, find func(context.Context) (*T, *http.Response, error)) (*T, UpsertResult, *http.Response, error) {
+	existing, resp, err := find(ctx)
+	if err != nil {
+		return nil, Unchanged, resp, err
+	}
+
+	body, err := toJSONMap(desired)
+	if err != nil {
+		return nil, Unchanged, nil, err
+	}
+
+	if existing == nil {
+		stored, resp, err := write[T](ctx, s, path, "", body)
+		return stored, Created, resp, err
+	}
+
+	current, err := toJSONMap(existing)
+	if err != nil {
+		return nil, Unchanged, nil, err
+	}
+	if contains(current, body) {
+		return existing, Unchanged, resp, nil
+	}
+
+	// PUT replaces the entity with the one sent, so send the existing one,
+	// identity included, with the desired fields laid over it
+	id, _ := current["id"].(string)
+	stored, resp, err := write[T](ctx, s, path, id, overlay(current, body))
+	return stored, Updated, resp, err
+}
+
+// write stores body with a PUT on the collection at path. When Kong
+// answers 405 Method Not Allowed it POSTs body to path instead, or
+// PATCHes path/id if id is set.
+func write[T any](ctx context.Context, s *service, path, id string, body map[string]interface{}) (*T, *http.Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPut, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stored := new(T)
+	resp, err := s.client.Do(req, stored)
+	if resp == nil || resp.StatusCode != http.StatusMethodNotAllowed {
+		if err != nil {
+			return nil, resp, err
+		}
+		return stored, resp, err
+	}
+
+	method, u := http.MethodPost, path
+	if id != "" {
+		method, u = http.MethodPatch, fmt.Sprintf("%v/%v", path, id)
+		delete(body, "created_at")
+	}
+
+	req, err = s.client.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stored = new(T)
+	resp, err = s.client.Do(req, stored)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return stored, resp, err
+}
+
+// found turns the *NotFoundError of a lookup into a nil entity, for the
+// find functions passed to upsert.
+func found[T any](v *T, resp *http.Response, err error) (*T, *http.Response, error) {
+	if IsNotFound(err) {
+		return nil, resp, nil
+	}
+	return v, resp, err
+}
+
+// toJSONMap returns the JSON object v encodes to, leaving out fields set
+// to null as Kong treats them as unset.
+func toJSONMap(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	for k, v := range m {
+		if v == nil {
+			delete(m, k)
+		}
+	}
+	return m, nil
+}
+
+// setFields returns the JSON object v encodes to without the fields
+// holding a zero value, except those named in force, for request types
+// such as ApiRequest whose fields are not omitempty. Leaving one of their
+// fields unset then leaves the current value, or Kong's default, alone.
+func setFields(v interface{}, force []string) (map[string]interface{}, error) {
+	m, err := toJSONMap(v)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := make(map[string]bool, len(force))
+	for _, k := range force {
+		keep[k] = true
+	}
+
+	for k, v := range m {
+		if keep[k] {
+			continue
+		}
+
+		switch v := v.(type) {
+		case bool:
+			if !v {
+				delete(m, k)
+			}
+		case float64:
+			if v == 0 {
+				delete(m, k)
+			}
+		case string:
+			if v == "" {
+				delete(m, k)
+			}
+		case []interface{}:
+			if len(v) == 0 {
+				delete(m, k)
+			}
+		}
+	}
+	return m, nil
+}
+
+// overlay sets the fields of src on dst, merging nested objects field by
+// field, and returns dst.
+func overlay(dst, src map[string]interface{}) map[string]interface{} {
+	for k, v := range src {
+		sm, srcIsMap := v.(map[string]interface{})
+		dm, dstIsMap := dst[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			overlay(dm, sm)
+			continue
+		}
+		dst[k] = v
+	}
+	return dst
+}
+
+// contains reports whether every field of want holds the same value in
+// have, comparing nested objects field by field.
+func contains(have, want map[string]interface{}) bool {
+	for k, w := range want {
+		h, ok := have[k]
+		if !ok {
+			return false
+		}
+
+		wm, wIsMap := w.(map[string]interface{})
+		hm, hIsMap := h.(map[string]interface{})
+		if wIsMap && hIsMap {
+			if !contains(hm, wm) {
+				return false
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(h, w) {
+			return false
+		}
+	}
+	return true
+}
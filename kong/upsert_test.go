@@ -0,0 +1,336 @@
+package kong
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestConsumersService_Upsert_created(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not found"}`)
+	})
+	mux.HandleFunc("/consumers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"username":"bob"}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"c1","username":"bob","created_at":1}`)
+	})
+
+	consumer, result, _, err := client.Consumers.Upsert(&Consumer{Username: "bob"})
+	if err != nil {
+		t.Errorf("Consumers.Upsert returned error: %v", err)
+	}
+
+	want := &Consumer{ID: "c1", Username: "bob", CreatedAt: 1}
+	if !reflect.DeepEqual(consumer, want) || result != Created {
+		t.Errorf("Consumers.Upsert returned %+v, %v, want %+v, created", consumer, result, want)
+	}
+}
+
+func TestConsumersService_Upsert_unchanged(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"c1","username":"bob","custom_id":"b","created_at":1}`)
+	})
+
+	consumer, result, _, err := client.Consumers.Upsert(&Consumer{Username: "bob", CustomID: "b"})
+	if err != nil {
+		t.Errorf("Consumers.Upsert returned error: %v", err)
+	}
+
+	want := &Consumer{ID: "c1", Username: "bob", CustomID: "b", CreatedAt: 1}
+	if !reflect.DeepEqual(consumer, want) || result != Unchanged {
+		t.Errorf("Consumers.Upsert returned %+v, %v, want %+v, unchanged", consumer, result, want)
+	}
+}
+
+func TestConsumersService_Upsert_updated(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"c1","username":"bob","created_at":1}`)
+	})
+	mux.HandleFunc("/consumers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"created_at":1,"custom_id":"b","id":"c1","username":"bob"}`+"\n")
+		fmt.Fprint(w, `{"id":"c1","username":"bob","custom_id":"b","created_at":1}`)
+	})
+
+	consumer, result, _, err := client.Consumers.Upsert(&Consumer{Username: "bob", CustomID: "b"})
+	if err != nil {
+		t.Errorf("Consumers.Upsert returned error: %v", err)
+	}
+
+	want := &Consumer{ID: "c1", Username: "bob", CustomID: "b", CreatedAt: 1}
+	if !reflect.DeepEqual(consumer, want) || result != Updated {
+		t.Errorf("Consumers.Upsert returned %+v, %v, want %+v, updated", consumer, result, want)
+	}
+}
+
+func TestConsumersService_Upsert_customID(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"custom_id": "b"})
+		fmt.Fprint(w, `{"total":1,"data":[{"id":"c1","custom_id":"b"}]}`)
+	})
+
+	_, result, _, err := client.Consumers.Upsert(&Consumer{CustomID: "b"})
+	if err != nil || result != Unchanged {
+		t.Errorf("Consumers.Upsert returned %v, %v, want unchanged", result, err)
+	}
+
+	if _, _, _, err := client.Consumers.Upsert(&Consumer{}); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestUpstreamsService_Upsert_withoutPut(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/upstreams/u", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"id":"u1","name":"u","slots":10000,"created_at":1}`)
+		case "PATCH":
+			t.Errorf("Request sent to the upstream name rather than its id")
+		}
+	})
+	mux.HandleFunc("/upstreams", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		fmt.Fprint(w, `{"message":"Method not allowed"}`)
+	})
+	mux.HandleFunc("/upstreams/u1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"id":"u1","name":"u","slots":100}`+"\n")
+		fmt.Fprint(w, `{"id":"u1","name":"u","slots":100,"created_at":1}`)
+	})
+
+	upstream, result, _, err := client.Upstreams.Upsert(&Upstream{Name: "u", Slots: 100})
+	if err != nil {
+		t.Errorf("Upstreams.Upsert returned error: %v", err)
+	}
+
+	want := &Upstream{ID: "u1", Name: "u", Slots: 100, CreatedAt: 1}
+	if !reflect.DeepEqual(upstream, want) || result != Updated {
+		t.Errorf("Upstreams.Upsert returned %+v, %v, want %+v, updated", upstream, result, want)
+	}
+}
+
+func TestPluginsService_Upsert(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/plugins", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"name": "cors", "api_id": "a1"})
+		// The consumer scoped instance must not be mistaken for the
+		// one applied to the api as a whole
+		fmt.Fprint(w, `{"total":2,"data":[
+			{"id":"p1","name":"cors","api_id":"a1","consumer_id":"c1","config":{"max_age":10}},
+			{"id":"p2","name":"cors","api_id":"a1","config":{"max_age":60,"origins":["*"]}}
+		]}`)
+	})
+
+	plugin := &Plugin{Name: "cors", ApiID: "a1", Config: map[string]interface{}{"max_age": 60}}
+	got, result, _, err := client.Plugins.Upsert(plugin)
+	if err != nil {
+		t.Errorf("Plugins.Upsert returned error: %v", err)
+	}
+	if got == nil || got.ID != "p2" || result != Unchanged {
+		t.Errorf("Plugins.Upsert returned %+v, %v, want p2, unchanged", got, result)
+	}
+}
+
+func TestPluginsService_Upsert_updated(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/plugins/p1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"p1","name":"cors","created_at":1,"config":{"max_age":10,"origins":["*"]}}`)
+	})
+	mux.HandleFunc("/plugins", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		// Config fields the caller leaves out keep their values
+		testBody(t, r, `{"config":{"max_age":60,"origins":["*"]},"created_at":1,"id":"p1","name":"cors"}`+"\n")
+		fmt.Fprint(w, `{"id":"p1","name":"cors","created_at":1,"config":{"max_age":60,"origins":["*"]}}`)
+	})
+
+	plugin := &Plugin{ID: "p1", Name: "cors", Config: map[string]interface{}{"max_age": 60}}
+	if _, result, _, err := client.Plugins.Upsert(plugin); err != nil || result != Updated {
+		t.Errorf("Plugins.Upsert returned %v, %v, want updated", result, err)
+	}
+}
+
+// kongApi is an api object as Kong returns it, with its defaults filled in.
+const kongApi = `{"id":"a1","name":"mockbin","upstream_url":"http://mockbin.org","created_at":1,` +
+	`"uris":["/mockbin"],"strip_uri":true,"retries":5,"upstream_connect_timeout":60000,` +
+	`"upstream_send_timeout":60000,"upstream_read_timeout":60000,"https_only":false,"http_if_terminated":false}`
+
+func TestApisService_Upsert_unchanged(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/apis/mockbin", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, kongApi)
+	})
+
+	api, result, _, err := client.Apis.Upsert(&ApiRequest{Name: "mockbin", UpstreamURL: "http://mockbin.org"})
+	if err != nil {
+		t.Errorf("Apis.Upsert returned error: %v", err)
+	}
+	if api == nil || api.ID != "a1" || result != Unchanged {
+		t.Errorf("Apis.Upsert returned %+v, %v, want a1, unchanged", api, result)
+	}
+}
+
+func TestApisService_Upsert_updated(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/apis/mockbin", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, kongApi)
+	})
+	mux.HandleFunc("/apis", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var got map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&got)
+		if got["upstream_url"] != "http://mockbin.com" || got["retries"] != float64(5) ||
+			got["strip_uri"] != true || got["upstream_read_timeout"] != float64(60000) || got["id"] != "a1" {
+			t.Errorf("Request body %v, want the current api with upstream_url changed", got)
+		}
+		fmt.Fprint(w, kongApi)
+	})
+
+	_, result, _, err := client.Apis.Upsert(&ApiRequest{Name: "mockbin", UpstreamURL: "http://mockbin.com"})
+	if err != nil || result != Updated {
+		t.Errorf("Apis.Upsert returned %v, %v, want updated", result, err)
+	}
+}
+
+func TestApisService_Upsert_forceSendFields(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/apis/mockbin", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, kongApi)
+	})
+	var writes int
+	mux.HandleFunc("/apis", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		writes++
+
+		var got map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&got)
+		if got["strip_uri"] != false || got["retries"] != float64(5) || got["id"] != "a1" {
+			t.Errorf("Request body %v, want the current api with strip_uri false", got)
+		}
+		fmt.Fprint(w, kongApi)
+	})
+
+	// Without ForceSendFields the false strip_uri is left unset
+	api := &ApiRequest{Name: "mockbin", StripUri: false}
+	if _, result, _, err := client.Apis.Upsert(api); err != nil || result != Unchanged {
+		t.Errorf("Apis.Upsert returned %v, %v, want unchanged", result, err)
+	}
+
+	api.ForceSendFields = []string{"strip_uri"}
+	if _, result, _, err := client.Apis.Upsert(api); err != nil || result != Updated {
+		t.Errorf("Apis.Upsert returned %v, %v, want updated", result, err)
+	}
+	if writes != 1 {
+		t.Errorf("Apis.Upsert wrote the api %d times, want 1", writes)
+	}
+}
+
+func TestApisService_Upsert_created(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/apis/mockbin", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not found"}`)
+	})
+	mux.HandleFunc("/apis", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		// Kong fills in the defaults of the fields left unset
+		testBody(t, r, `{"name":"mockbin","retries":3,"upstream_url":"http://mockbin.org"}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, kongApi)
+	})
+
+	api := &ApiRequest{Name: "mockbin", UpstreamURL: "http://mockbin.org", Retries: 3}
+	if _, result, _, err := client.Apis.Upsert(api); err != nil || result != Created {
+		t.Errorf("Apis.Upsert returned %v, %v, want created", result, err)
+	}
+
+	if _, _, _, err := client.Apis.Upsert(&ApiRequest{}); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestConsumersKeyAuthService_Upsert(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/consumers/bob/key-auth/secret", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not found"}`)
+	})
+	mux.HandleFunc("/consumers/bob/key-auth", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"key":"secret"}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"k1","key":"secret","consumer_id":"c1"}`)
+	})
+
+	key, result, _, err := client.Consumers.Plugins.KeyAuth.Upsert("bob", &ConsumerKeyAuthConfig{Key: "secret"})
+	if err != nil {
+		t.Errorf("KeyAuth.Upsert returned error: %v", err)
+	}
+
+	want := &ConsumerKeyAuthConfig{ID: "k1", Key: "secret", ConsumerID: "c1"}
+	if !reflect.DeepEqual(key, want) || result != Created {
+		t.Errorf("KeyAuth.Upsert returned %+v, %v, want %+v, created", key, result, want)
+	}
+
+	if _, _, _, err := client.Consumers.Plugins.KeyAuth.Upsert("bob", &ConsumerKeyAuthConfig{}); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestUpsertResult_String(t *testing.T) {
+	for result, want := range map[UpsertResult]string{
+		Unchanged:       "unchanged",
+		Created:         "created",
+		Updated:         "updated",
+		UpsertResult(7): "UpsertResult(7)",
+	} {
+		if got := result.String(); got != want {
+			t.Errorf("UpsertResult(%d).String() = %q, want %q", int(result), got, want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package kong
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -25,21 +26,91 @@ type Upstreams struct {
 }
 
 // Upstream represents a single Kong upstream object.
+//
+// HashOn and HashFallback select what the consistent hashing load
+// balancer hashes on: one of "none", "consumer", "ip", "header" or
+// "cookie". HashOnHeader and HashFallbackHeader name the header when
+// "header" is used.
 type Upstream struct {
-	Name      string `json:"name"`
-	ID        string `json:"id,omitempty"`
-	CreatedAt int64  `json:"created_at,omitempty"`
-	Slots     int    `json:"slots,omitempty"`
-	Orderlist []int  `json:"orderliste,omitempty"`
+	Name               string        `json:"name"`
+	ID                 string        `json:"id,omitempty"`
+	CreatedAt          int64         `json:"created_at,omitempty"`
+	Slots              int           `json:"slots,omitempty"`
+	Orderlist          []int         `json:"orderlist,omitempty"`
+	HashOn             string        `json:"hash_on,omitempty"`
+	HashFallback       string        `json:"hash_fallback,omitempty"`
+	HashOnHeader       string        `json:"hash_on_header,omitempty"`
+	HashFallbackHeader string        `json:"hash_fallback_header,omitempty"`
+	HashOnCookie       string        `json:"hash_on_cookie,omitempty"`
+	HashOnCookiePath   string        `json:"hash_on_cookie_path,omitempty"`
+	Healthchecks       *Healthchecks `json:"healthchecks,omitempty"`
+}
+
+// Healthchecks represents the health checker configuration of an upstream.
+//
+// Active checks periodically probe each target, passive checks (also
+// known as circuit breakers) watch the proxied traffic.
+type Healthchecks struct {
+	Active  *ActiveHealthcheck  `json:"active,omitempty"`
+	Passive *PassiveHealthcheck `json:"passive,omitempty"`
+}
+
+// ActiveHealthcheck configures the probes Kong sends to the targets of an
+// upstream.
+type ActiveHealthcheck struct {
+	Type                   string     `json:"type,omitempty"`
+	Timeout                int        `json:"timeout,omitempty"`
+	Concurrency            int        `json:"concurrency,omitempty"`
+	HTTPPath               string     `json:"http_path,omitempty"`
+	HTTPSVerifyCertificate *bool      `json:"https_verify_certificate,omitempty"`
+	HTTPSSni               string     `json:"https_sni,omitempty"`
+	Healthy                *Healthy   `json:"healthy,omitempty"`
+	Unhealthy              *Unhealthy `json:"unhealthy,omitempty"`
+}
+
+// PassiveHealthcheck configures how Kong judges the health of targets
+// from the responses to proxied requests.
+type PassiveHealthcheck struct {
+	Type      string     `json:"type,omitempty"`
+	Healthy   *Healthy   `json:"healthy,omitempty"`
+	Unhealthy *Unhealthy `json:"unhealthy,omitempty"`
+}
+
+// Healthy holds the thresholds after which a target is considered healthy.
+//
+// The counters are pointers because 0 is meaningful: it disables the
+// check. Interval only applies to active health checks.
+type Healthy struct {
+	Interval     *int  `json:"interval,omitempty"`
+	HTTPStatuses []int `json:"http_statuses,omitempty"`
+	Successes    *int  `json:"successes,omitempty"`
+}
+
+// Unhealthy holds the thresholds after which a target is considered
+// unhealthy.
+//
+// The counters are pointers because 0 is meaningful: it disables the
+// check. Interval only applies to active health checks.
+type Unhealthy struct {
+	Interval     *int  `json:"interval,omitempty"`
+	HTTPStatuses []int `json:"http_statuses,omitempty"`
+	TCPFailures  *int  `json:"tcp_failures,omitempty"`
+	Timeouts     *int  `json:"timeouts,omitempty"`
+	HTTPFailures *int  `json:"http_failures,omitempty"`
 }
 
 // Get queries for a single Kong upstream object, by name or id.
 //
 // Equivalent to GET /upstreams/{name or id}
 func (s *UpstreamsService) Get(upstream string) (*Upstream, *http.Response, error) {
+	return s.GetWithContext(context.Background(), upstream)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *UpstreamsService) GetWithContext(ctx context.Context, upstream string) (*Upstream, *http.Response, error) {
 	u := fmt.Sprintf("upstreams/%v", upstream)
 
-	req, err := s.client.NewRequest("GET", u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -55,37 +126,64 @@ func (s *UpstreamsService) Get(upstream string) (*Upstream, *http.Response, erro
 
 // Patch updates an existing Kong upstream object.
 // At least one of upstream.Name or upstream.ID must be specified in
-// the passed *Upstream parameter.
+// the passed *Upstream parameter. Use Update to get the updated object
+// back.
 //
 // Equivalent to PATCH /upstreams/{name or id}
 func (s *UpstreamsService) Patch(upstream *Upstream) (*http.Response, error) {
+	return s.PatchWithContext(context.Background(), upstream)
+}
+
+// PatchWithContext is like Patch but uses ctx for the request.
+func (s *UpstreamsService) PatchWithContext(ctx context.Context, upstream *Upstream) (*http.Response, error) {
+	_, resp, err := s.UpdateWithContext(ctx, upstream)
+	return resp, err
+}
+
+// Update is like Patch but returns the updated Kong upstream object.
+//
+// Equivalent to PATCH /upstreams/{name or id}
+func (s *UpstreamsService) Update(upstream *Upstream) (*Upstream, *http.Response, error) {
+	return s.UpdateWithContext(context.Background(), upstream)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *UpstreamsService) UpdateWithContext(ctx context.Context, upstream *Upstream) (*Upstream, *http.Response, error) {
 	var u string
 	if upstream.Name != "" {
 		u = fmt.Sprintf("upstreams/%v", upstream.Name)
 	} else if upstream.ID != "" {
 		u = fmt.Sprintf("upstreams/%v", upstream.ID)
 	} else {
-		return nil, errors.New("At least one of upstream.Name or upstream.ID must be specified")
+		return nil, nil, errors.New("At least one of upstream.Name or upstream.ID must be specified")
 	}
 
-	req, err := s.client.NewRequest("PATCH", u, upstream)
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPatch, u, upstream)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	resp, err := s.client.Do(req, nil)
-
-	return resp, err
+	uResp := new(Upstream)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
 
+	return uResp, resp, err
 }
 
 // Delete deletes a single Kong upstream object, by name or id.
 //
 // Equivalent to DELETE /upstreams/{name or id}
 func (s *UpstreamsService) Delete(upstream string) (*http.Response, error) {
+	return s.DeleteWithContext(context.Background(), upstream)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *UpstreamsService) DeleteWithContext(ctx context.Context, upstream string) (*http.Response, error) {
 	u := fmt.Sprintf("upstreams/%v", upstream)
 
-	req, err := s.client.NewRequest("DELETE", u, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "DELETE", u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -98,16 +196,145 @@ func (s *UpstreamsService) Delete(upstream string) (*http.Response, error) {
 	return resp, err
 }
 
-// Post creates a new Kong upstream object.
+// Post creates a new Kong upstream object. Use Create to get the created
+// object back.
 //
 // Equivalent to POST /upstreams
 func (s *UpstreamsService) Post(upstream *Upstream) (*http.Response, error) {
-	req, err := s.client.NewRequest("POST", "upstreams", upstream)
+	return s.PostWithContext(context.Background(), upstream)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (s *UpstreamsService) PostWithContext(ctx context.Context, upstream *Upstream) (*http.Response, error) {
+	_, resp, err := s.CreateWithContext(ctx, upstream)
+	return resp, err
+}
+
+// Create is like Post but returns the created Kong upstream object,
+// including its id and the defaults Kong filled in, such as the
+// healthchecks.
+//
+// Equivalent to POST /upstreams
+func (s *UpstreamsService) Create(upstream *Upstream) (*Upstream, *http.Response, error) {
+	return s.CreateWithContext(context.Background(), upstream)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *UpstreamsService) CreateWithContext(ctx context.Context, upstream *Upstream) (*Upstream, *http.Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodPost, "upstreams", upstream)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	resp, err := s.client.Do(req, nil)
+	uResp := new(Upstream)
+	resp, err := s.client.Do(req, uResp)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return resp, err
+	return uResp, resp, err
+}
+
+// Upsert creates the Kong upstream object named upstream.Name, or
+// identified by upstream.ID, or updates it to match upstream. It reports
+// whether the upstream was created, updated or already matched.
+//
+// Equivalent to PUT /upstreams, or to POST /upstreams or
+// PATCH /upstreams/{id} when Kong does not accept PUT
+func (s *UpstreamsService) Upsert(upstream *Upstream) (*Upstream, UpsertResult, *http.Response, error) {
+	return s.UpsertWithContext(context.Background(), upstream)
+}
+
+// UpsertWithContext is like Upsert but uses ctx for the requests.
+func (s *UpstreamsService) UpsertWithContext(ctx context.Context, upstream *Upstream) (*Upstream, UpsertResult, *http.Response, error) {
+	key := upstream.Name
+	if key == "" {
+		key = upstream.ID
+	}
+	if key == "" {
+		return nil, Unchanged, nil, errors.New("At least one of upstream.Name or upstream.ID must be specified")
+	}
+
+	return upsert(ctx, s.service, "upstreams", upstream, func(ctx context.Context) (*Upstream, *http.Response, error) {
+		return found(s.GetWithContext(ctx, key))
+	})
+}
+
+// UpstreamsGetAllOptions specifies optional filter parameters to the
+// UpstreamsService.GetAll method.
+//
+// Additional information about filtering options can be found in
+// the Kong documentation at:
+// https://getkong.org/docs/0.12.x/admin-api/#list-upstreams
+type UpstreamsGetAllOptions struct {
+	ID     string `url:"id,omitempty"`     // A filter on the list based on the upstream id field.
+	Name   string `url:"name,omitempty"`   // A filter on the list based on the upstream name field.
+	Slots  int    `url:"slots,omitempty"`  // A filter on the list based on the upstream slots field.
+	Size   int    `url:"size,omitempty"`   // A limit on the number of objects to be returned.
+	Offset string `url:"offset,omitempty"` // A cursor used for pagination. offset is an object identifier that defines a place in the list.
+}
+
+// GetAll queries for all Kong upstream objects.
+// This query can be filtered by supplying the UpstreamsGetAllOptions struct.
+//
+// Equivalent to GET /upstreams?uri=params&from=opt
+func (s *UpstreamsService) GetAll(opt *UpstreamsGetAllOptions) (*Upstreams, *http.Response, error) {
+	return s.GetAllWithContext(context.Background(), opt)
+}
+
+// GetAllWithContext is like GetAll but uses ctx for the request.
+func (s *UpstreamsService) GetAllWithContext(ctx context.Context, opt *UpstreamsGetAllOptions) (*Upstreams, *http.Response, error) {
+	u, err := addOptions("upstreams", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	upstreams := new(Upstreams)
+	resp, err := s.client.Do(req, upstreams)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return upstreams, resp, err
+}
+
+// Iterator returns an Iterator over all Kong upstream objects.
+// opt.Size sets the page size and opt.Offset the starting point,
+// the remaining fields filter the results as in GetAll.
+func (s *UpstreamsService) Iterator(opt *UpstreamsGetAllOptions) *Iterator[Upstream] {
+	return s.IteratorWithContext(context.Background(), opt)
+}
+
+// IteratorWithContext is like Iterator but uses ctx for every page request.
+func (s *UpstreamsService) IteratorWithContext(ctx context.Context, opt *UpstreamsGetAllOptions) *Iterator[Upstream] {
+	o := new(UpstreamsGetAllOptions)
+	if opt != nil {
+		*o = *opt
+	}
+
+	return newIterator(o.Offset, func(offset string) ([]*Upstream, string, error) {
+		o.Offset = offset
+		upstreams, _, err := s.GetAllWithContext(ctx, o)
+		if err != nil {
+			return nil, "", err
+		}
+		return upstreams.Data, nextOffset(upstreams.Next, upstreams.Offset), nil
+	})
+}
+
+// ListAll follows Kong's pagination cursor and returns every upstream
+// object matching opt. If max is greater than zero no more than max
+// objects are returned.
+func (s *UpstreamsService) ListAll(opt *UpstreamsGetAllOptions, max int) ([]*Upstream, error) {
+	return s.ListAllWithContext(context.Background(), opt, max)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for every page request.
+func (s *UpstreamsService) ListAllWithContext(ctx context.Context, opt *UpstreamsGetAllOptions, max int) ([]*Upstream, error) {
+	return s.IteratorWithContext(ctx, opt).all(max)
 }
@@ -1,6 +1,7 @@
 package kong
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -205,6 +206,155 @@ func TestUpstream_Post_badStatusCode(t *testing.T) {
 	}
 }
 
+func TestUpstream_Create(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/upstreams", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"i","name":"n","slots":1000,"hash_on":"none"}`)
+	})
+
+	upstream, _, err := client.Upstreams.Create(&Upstream{Name: "n"})
+	if err != nil {
+		t.Errorf("Upstreams.Create returned error: %v", err)
+	}
+
+	want := &Upstream{ID: "i", Name: "n", Slots: 1000, HashOn: "none"}
+	if !reflect.DeepEqual(upstream, want) {
+		t.Errorf("Upstreams.Create returned %+v, want %+v", upstream, want)
+	}
+}
+
+func TestUpstream_Update(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/upstreams/n", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		fmt.Fprint(w, `{"id":"i","name":"n","slots":10}`)
+	})
+
+	upstream, _, err := client.Upstreams.Update(&Upstream{Name: "n", Slots: 10})
+	if err != nil {
+		t.Errorf("Upstreams.Update returned error: %v", err)
+	}
+
+	want := &Upstream{ID: "i", Name: "n", Slots: 10}
+	if !reflect.DeepEqual(upstream, want) {
+		t.Errorf("Upstreams.Update returned %+v, want %+v", upstream, want)
+	}
+
+	if _, _, err := client.Upstreams.Update(&Upstream{}); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestUpstream_marshal(t *testing.T) {
+	u := &Upstream{
+		Name:         "n",
+		Orderlist:    []int{2, 1},
+		HashOn:       "header",
+		HashFallback: "ip",
+		HashOnHeader: "X-User",
+		Healthchecks: &Healthchecks{
+			Active: &ActiveHealthcheck{
+				HTTPPath: "/status",
+				Healthy:  &Healthy{Interval: Int(5), Successes: Int(2)},
+				Unhealthy: &Unhealthy{
+					Interval:     Int(5),
+					HTTPStatuses: []int{500, 503},
+					HTTPFailures: Int(0),
+				},
+			},
+			Passive: &PassiveHealthcheck{
+				Unhealthy: &Unhealthy{TCPFailures: Int(3)},
+			},
+		},
+	}
+	want := `{
+		"name": "n",
+		"orderlist": [2, 1],
+		"hash_on": "header",
+		"hash_fallback": "ip",
+		"hash_on_header": "X-User",
+		"healthchecks": {
+			"active": {
+				"http_path": "/status",
+				"healthy": {"interval": 5, "successes": 2},
+				"unhealthy": {"interval": 5, "http_statuses": [500, 503], "http_failures": 0}
+			},
+			"passive": {
+				"unhealthy": {"tcp_failures": 3}
+			}
+		}
+	}`
+	testJSONMarshal(t, u, want)
+}
+
+func TestUpstream_GetAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	v := &Upstreams{Total: 1, Offset: "o2", Data: []*Upstream{sampleUpstream()}}
+
+	mux.HandleFunc("/upstreams", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"name": "upstreamName", "size": "10", "offset": "o"})
+		json.NewEncoder(w).Encode(v)
+	})
+
+	opt := &UpstreamsGetAllOptions{Name: "upstreamName", Size: 10, Offset: "o"}
+	upstreams, _, err := client.Upstreams.GetAll(opt)
+	if err != nil {
+		t.Errorf("Upstreams.GetAll returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(upstreams, v) {
+		t.Errorf("Upstreams.GetAll returned %+v, want %+v", upstreams, v)
+	}
+}
+
+func TestUpstream_GetAll_badStatusCode(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/upstreams", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+		fmt.Fprint(w, `{"error":"e"}`)
+	})
+
+	_, _, err := client.Upstreams.GetAll(nil)
+	if err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestUpstream_ListAll(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/upstreams", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprint(w, `{"data":[{"name":"a"}],"next":"http://kong/upstreams?offset=o"}`)
+		case "o":
+			fmt.Fprint(w, `{"data":[{"name":"b"}]}`)
+		}
+	})
+
+	upstreams, err := client.Upstreams.ListAll(nil, 0)
+	if err != nil {
+		t.Errorf("Upstreams.ListAll returned error: %v", err)
+	}
+
+	want := []*Upstream{{Name: "a"}, {Name: "b"}}
+	if !reflect.DeepEqual(upstreams, want) {
+		t.Errorf("Upstreams.ListAll returned %+v, want %+v", upstreams, want)
+	}
+}
+
 func sampleUpstream() *Upstream {
 	return &Upstream{
 		Name:      "upstreamName",
@@ -213,3 +363,20 @@ func sampleUpstream() *Upstream {
 		Orderlist: []int{4, 1, 3, 2},
 	}
 }
+
+func TestUpstream_PostWithContext_canceled(t *testing.T) {
+	stubSetup()
+	defer stubTeardown()
+
+	mux.HandleFunc("/upstreams", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Request should not have reached the server")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.Upstreams.PostWithContext(ctx, sampleUpstream())
+	if err != context.Canceled {
+		t.Errorf("Upstreams.PostWithContext returned error %v, want %v", err, context.Canceled)
+	}
+}